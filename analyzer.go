@@ -0,0 +1,45 @@
+package wordfreq
+
+// Analyzer is the compiled configuration of New, without any count. It
+// is safe for concurrent use, e.g. shared by the handlers of a web
+// server, each counting in a Counter of its own.
+type Analyzer struct {
+	config *WordFeq // never processes anything
+}
+
+// Counter accumulates the counts of the texts it processes, configured by
+// the Analyzer it comes from.
+type Counter = WordFeq
+
+// NewAnalyzer compiles the options, as New does.
+func NewAnalyzer(ops Options) (*Analyzer, error) {
+	w, err := New(ops)
+	if err != nil {
+		return nil, err
+	}
+	return &Analyzer{w}, nil
+}
+
+// NewCounter returns an empty Counter sharing the configuration of the
+// analyzer, much cheaper than New.
+func (a *Analyzer) NewCounter() *Counter {
+	c := a.config.shard()
+	c.options.Metrics = a.config.options.Metrics
+	return c
+}
+
+// Tokens returns the tokens of the text by the language, nil for an
+// unknown language, as the Tokenizer of the language does.
+func (a *Analyzer) Tokens(lang, text string) []Token {
+	t := a.config.shard().Tokenizer(lang)
+	if t == nil {
+		return nil
+	}
+	return t.Tokens(text)
+}
+
+// Tokenize returns the terms the text is counted as, as
+// WordFeq.Tokenize does.
+func (a *Analyzer) Tokenize(text string) []string {
+	return a.config.Tokenize(text)
+}
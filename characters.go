@@ -0,0 +1,38 @@
+package wordfreq
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Count every letter of the text on its own, Han characters and Latin
+// letters alike, in lower case unless Options.CaseSensitive.
+func (w *WordFeq) processCharacters(text string, pushTerm func(string, int), pushOccurrence func(string, int, int)) {
+	counts := make(map[string]int)
+	for _, token := range w.characterTokens(text) {
+		counts[token.Text]++
+		if pushOccurrence != nil {
+			pushOccurrence(token.Text, token.Start, token.End)
+		}
+	}
+	for character, n := range counts {
+		pushTerm(character, n)
+	}
+}
+
+// The letters of the text.
+func (w *WordFeq) characterTokens(text string) []Token {
+	tokens := make([]Token, 0)
+	for i, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+
+		character := string(r)
+		if !w.options.CaseSensitive {
+			character = w.lower(character)
+		}
+		tokens = append(tokens, Token{character, i, i + utf8.RuneLen(r)})
+	}
+	return tokens
+}
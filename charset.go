@@ -0,0 +1,109 @@
+package wordfreq
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+)
+
+// Input charsets of Options.Charset, besides 'auto'.
+var charsets = map[string]encoding.Encoding{
+	"utf-8":     nil,
+	"big5":      traditionalchinese.Big5,
+	"gbk":       simplifiedchinese.GBK,
+	"shift-jis": japanese.ShiftJIS,
+	"latin-1":   charmap.ISO8859_1,
+}
+
+// Size of the sample DetectCharset is given by the readers.
+const charsetSampleSize = 4096
+
+var (
+	// the most frequent characters of Chinese texts, all decoding to
+	// rare characters with the wrong charset
+	commonHan         = "的一是不了在人有我他中上大和地到以要就出可也你生能而子那得下自之年作"
+	commonTraditional = "這個們來為國說時會對發後過於著裡" + commonHan
+	commonSimplified  = "這个们来为国说时会对发后过于着里" + commonHan
+)
+
+// DetectCharset guesses the charset of a text: 'utf-8', 'big5', 'gbk',
+// 'shift-jis', or 'latin-1' when nothing else fits.
+func DetectCharset(text []byte) string {
+	return detectCharset(text, false)
+}
+
+// Guess the charset of a sample of text, which is cut in the middle of
+// a character maybe.
+func detectCharset(sample []byte, cut bool) string {
+	if utf8.Valid(sample) || (cut && validUTF8Prefix(sample)) {
+		return "utf-8"
+	}
+
+	best, bestScore := "latin-1", 0
+	for _, charset := range []string{"gbk", "big5", "shift-jis"} {
+		decoded, err := charsets[charset].NewDecoder().Bytes(sample)
+		if err != nil {
+			continue
+		}
+		text := string(decoded)
+		if cut {
+			text = strings.TrimSuffix(text, string(utf8.RuneError))
+		}
+		if strings.ContainsRune(text, utf8.RuneError) {
+			continue
+		}
+
+		score := 0
+		for _, r := range text {
+			switch {
+			case charset == "shift-jis" && (unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r)):
+				score += 2
+			case charset == "gbk" && strings.ContainsRune(commonSimplified, r):
+				score++
+			case charset == "big5" && strings.ContainsRune(commonTraditional, r):
+				score++
+			}
+		}
+		if score > bestScore {
+			best, bestScore = charset, score
+		}
+	}
+	return best
+}
+
+// Whether the sample is valid UTF-8 but for a character cut at its end.
+func validUTF8Prefix(sample []byte) bool {
+	i := len(sample) - 1
+	for i > 0 && i > len(sample)-utf8.UTFMax && !utf8.RuneStart(sample[i]) {
+		i--
+	}
+	return i >= 0 && !utf8.FullRune(sample[i:]) && utf8.Valid(sample[:i])
+}
+
+// Return a reader of r transcoded from the charset to UTF-8, detecting
+// the charset on the first bytes with 'auto'.
+func transcode(r io.Reader, charset string) (io.Reader, error) {
+	if charset == "auto" {
+		br := bufio.NewReaderSize(r, charsetSampleSize)
+		sample, err := br.Peek(charsetSampleSize)
+		if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+			return nil, err
+		}
+		charset = detectCharset(sample, len(sample) == charsetSampleSize)
+		r = br
+	}
+
+	enc := charsets[charset]
+	if enc == nil {
+		return r, nil
+	}
+	return enc.NewDecoder().Reader(r), nil
+}
@@ -0,0 +1,153 @@
+// Package cloud lays out the terms of a WordFeq list into an SVG word
+// cloud.
+package cloud
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"unicode"
+
+	"github.com/twsiyuan/wordfreq"
+)
+
+type Options struct {
+	Width       int     // Default: 800
+	Height      int     // Default: 600
+	MinFontSize float64 // Default: 10
+	MaxFontSize float64 // Default: 64
+	FontFamily  string  // Default: 'sans-serif'
+	Top         int     // number of terms laid out, Default: 100
+}
+
+// Colors of the words, in turn.
+var palette = []string{"#1f77b4", "#ff7f0e", "#2ca02c", "#d62728", "#9467bd", "#8c564b", "#e377c2", "#17becf"}
+
+// A word laid out, its box centered on x, y.
+type word struct {
+	term          string
+	size          float64
+	x, y          float64
+	width, height float64
+}
+
+func (a word) overlaps(b word) bool {
+	return math.Abs(a.x-b.x)*2 < a.width+b.width && math.Abs(a.y-b.y)*2 < a.height+b.height
+}
+
+// WriteSVG lays out the terms, e.g. from List, the most frequent first
+// at the center, each being moved along a spiral until it overlaps none
+// of the words placed before, its font size growing with the logarithm
+// of its count. The words finding no room are left out.
+func WriteSVG(out io.Writer, terms []wordfreq.Term, opts Options) error {
+	if opts.Width <= 0 {
+		opts.Width = 800
+	}
+	if opts.Height <= 0 {
+		opts.Height = 600
+	}
+	if opts.MinFontSize <= 0 {
+		opts.MinFontSize = 10
+	}
+	if opts.MaxFontSize <= 0 {
+		opts.MaxFontSize = 64
+	}
+	if opts.MaxFontSize < opts.MinFontSize {
+		opts.MaxFontSize = opts.MinFontSize
+	}
+	if opts.FontFamily == "" {
+		opts.FontFamily = "sans-serif"
+	}
+	if opts.Top <= 0 {
+		opts.Top = 100
+	}
+	if len(terms) > opts.Top {
+		terms = terms[:opts.Top]
+	}
+
+	words := layout(terms, opts)
+
+	b := bufio.NewWriter(out)
+	fmt.Fprintf(b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\" font-family=\"", opts.Width, opts.Height, opts.Width, opts.Height)
+	xml.EscapeText(b, []byte(opts.FontFamily))
+	b.WriteString("\" text-anchor=\"middle\" dominant-baseline=\"central\">\n")
+	for i, w := range words {
+		fmt.Fprintf(b, "\t<text x=\"%.1f\" y=\"%.1f\" font-size=\"%.1f\" fill=\"%s\">", w.x, w.y, w.size, palette[i%len(palette)])
+		xml.EscapeText(b, []byte(w.term))
+		b.WriteString("</text>\n")
+	}
+	b.WriteString("</svg>\n")
+	return b.Flush()
+}
+
+func layout(terms []wordfreq.Term, opts Options) []word {
+	if len(terms) == 0 {
+		return nil
+	}
+
+	min, max := terms[0].Count, terms[0].Count
+	for _, t := range terms {
+		if t.Count < min {
+			min = t.Count
+		}
+		if t.Count > max {
+			max = t.Count
+		}
+	}
+
+	cx, cy := float64(opts.Width)/2, float64(opts.Height)/2
+	limit := math.Hypot(cx, cy)
+	placed := make([]word, 0, len(terms))
+	for _, t := range terms {
+		size := opts.MinFontSize
+		if max > min {
+			size += (opts.MaxFontSize - opts.MinFontSize) * math.Log(float64(t.Count)/float64(min)) / math.Log(float64(max)/float64(min))
+		}
+		w := word{term: t.Term, size: size, width: textWidth(t.Term, size), height: size}
+
+		// along an Archimedean spiral from the center
+		for step := 0.0; ; step += 0.1 {
+			r := 2 * step
+			if r > limit {
+				break
+			}
+			w.x = cx + r*math.Cos(step)
+			w.y = cy + r*math.Sin(step)
+			if fits(w, placed, opts) {
+				placed = append(placed, w)
+				break
+			}
+		}
+	}
+	return placed
+}
+
+// Whether the word is within the picture and overlaps no placed word.
+func fits(w word, placed []word, opts Options) bool {
+	if w.x-w.width/2 < 0 || w.x+w.width/2 > float64(opts.Width) ||
+		w.y-w.height/2 < 0 || w.y+w.height/2 > float64(opts.Height) {
+		return false
+	}
+	for _, p := range placed {
+		if w.overlaps(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// Estimated width of the text, the CJK characters being about square and
+// the others about half as wide.
+func textWidth(text string, size float64) float64 {
+	width := 0.0
+	for _, r := range text {
+		if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+			width += size
+		} else {
+			width += 0.6 * size
+		}
+	}
+	return width
+}
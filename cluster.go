@@ -0,0 +1,108 @@
+package wordfreq
+
+import "sort"
+
+// Length, in runes, of the substring two Chinese phrases share to be
+// clustered together.
+const clusterOverlap = 3
+
+// Cluster of related listed terms, the most frequent first.
+type Cluster struct {
+	Term  string // the most frequent term
+	Count int    // sum of the counts of the terms
+	Terms []Term
+}
+
+type byClusterCount []Cluster
+
+func (s byClusterCount) Len() int {
+	return len(s)
+}
+func (s byClusterCount) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+func (s byClusterCount) Less(i, j int) bool {
+	c1 := s[i]
+	c2 := s[j]
+	if c1.Count == c2.Count {
+		return c1.Term < c2.Term
+	} else {
+		return c1.Count > c2.Count
+	}
+}
+
+// Clusters groups the listed terms sharing a stem, e.g. the differently
+// cased words with Options.CaseSensitive, the spelling variants and the
+// compounds kept whole, and the Chinese phrases sharing a substring of
+// 3 characters. The clusters are sorted by descending count, the terms
+// related to none making clusters of their own.
+func (w *WordFeq) Clusters() []Cluster {
+	w.sync()
+
+	// union-find over the positions in the list
+	parent := make([]int, len(w.list))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	union := func(i, j int) {
+		if i, j = find(i), find(j); i != j {
+			// the most frequent term is the root
+			if w.list[j].Count > w.list[i].Count {
+				i, j = j, i
+			}
+			parent[j] = i
+		}
+	}
+
+	seen := make(map[string]int)
+	link := func(key string, i int) {
+		if j, ok := seen[key]; ok {
+			union(j, i)
+		} else {
+			seen[key] = i
+		}
+	}
+	for i, t := range w.list {
+		switch t.Language {
+		case "english":
+			stem := w.stems.stem(w.lower(t.Term))
+			if spelling, ok := w.spellings[stem]; ok {
+				stem = spelling
+			}
+			link("english:"+stem, i)
+		case "chinese":
+			runes := []rune(t.Term)
+			for j := 0; j+clusterOverlap <= len(runes); j++ {
+				link("chinese:"+string(runes[j:j+clusterOverlap]), i)
+			}
+		}
+	}
+
+	clusters := make([]Cluster, 0)
+	index := make(map[int]int)
+	for i, t := range w.list {
+		root := find(i)
+		k, ok := index[root]
+		if !ok {
+			k = len(clusters)
+			index[root] = k
+			clusters = append(clusters, Cluster{})
+		}
+		clusters[k].Count += t.Count
+		clusters[k].Terms = append(clusters[k].Terms, t)
+	}
+	for i, c := range clusters {
+		sort.Sort(byTerm(c.Terms))
+		clusters[i].Term = c.Terms[0].Term
+	}
+	sort.Sort(byClusterCount(clusters))
+
+	return clusters
+}
@@ -0,0 +1,59 @@
+// Command wordfreq counts the terms of the files given, or of the
+// standard input, and writes them in the format of an encoder registered
+// with wordfreq.RegisterEncoder:
+//
+//	wordfreq [-format tsv] [-languages english,chinese] [-min-count 2] [file ...]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/twsiyuan/wordfreq"
+)
+
+func main() {
+	format := flag.String("format", "tsv", "output format: "+strings.Join(wordfreq.EncoderNames(), ", "))
+	languages := flag.String("languages", "", "comma separated languages, english and chinese by default")
+	minCount := flag.Int("min-count", 2, "minimal count of the listed terms")
+	flag.Parse()
+
+	if err := run(*format, *languages, *minCount, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(format, languages string, minCount int, files []string) error {
+	encoder, ok := wordfreq.LookupEncoder(format)
+	if !ok {
+		return fmt.Errorf("wordfreq: unknown format %q", format)
+	}
+
+	ops := wordfreq.Options{MinimumCount: minCount}
+	switch format {
+	case "dot", "graphml":
+		// the graphs are of the co-occurrences
+		ops.Cooccurrence = true
+	}
+	if languages != "" {
+		ops.Languages = strings.Split(languages, ",")
+	}
+	w, err := wordfreq.New(ops)
+	if err != nil {
+		return err
+	}
+
+	if len(files) > 0 {
+		_, err = w.ProcessFiles(files...)
+	} else {
+		_, err = w.ProcessReader(os.Stdin)
+	}
+	if err != nil {
+		return err
+	}
+
+	return encoder.Encode(os.Stdout, w)
+}
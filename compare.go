@@ -0,0 +1,160 @@
+package wordfreq
+
+import (
+	"math"
+	"sort"
+)
+
+type KeynessTerm struct {
+	Term           string
+	Count          int
+	ReferenceCount int
+	Keyness        float64 // log-likelihood, negative if relatively less frequent than in the reference
+}
+
+type byKeyness []KeynessTerm
+
+func (s byKeyness) Len() int {
+	return len(s)
+}
+func (s byKeyness) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+func (s byKeyness) Less(i, j int) bool {
+	t1 := s[i]
+	t2 := s[j]
+	if t1.Keyness == t2.Keyness {
+		return t1.Term < t2.Term
+	} else {
+		return t1.Keyness > t2.Keyness
+	}
+}
+
+// Compare computes the log-likelihood keyness of every term against
+// a reference corpus, most distinctive terms of this corpus first.
+func (w *WordFeq) Compare(reference *WordFeq) []KeynessTerm {
+	c := float64(w.TotalTokens())
+	d := float64(reference.TotalTokens())
+
+	list := make([]KeynessTerm, 0, len(w.terms))
+	push := func(term string, a, b int) {
+		list = append(list, KeynessTerm{term, a, b, logLikelihood(float64(a), float64(b), c, d)})
+	}
+	for term, termCount := range w.terms {
+		push(w.bareTerm(term), termCount, reference.terms[term])
+	}
+	for term, termCount := range reference.terms {
+		if _, ok := w.terms[term]; !ok {
+			push(reference.bareTerm(term), 0, termCount)
+		}
+	}
+	sort.Sort(byKeyness(list))
+
+	return list
+}
+
+// Signed log-likelihood (G2) of a term counted a times in a corpus of
+// c tokens and b times in a corpus of d tokens.
+func logLikelihood(a, b, c, d float64) float64 {
+	if c+d == 0 {
+		return 0
+	}
+
+	e1 := c * (a + b) / (c + d)
+	e2 := d * (a + b) / (c + d)
+
+	g2 := 0.0
+	if a > 0 {
+		g2 += a * math.Log(a/e1)
+	}
+	if b > 0 {
+		g2 += b * math.Log(b/e2)
+	}
+	g2 *= 2
+
+	// relatively less frequent than in the reference
+	if a*d < b*c {
+		g2 = -g2
+	}
+	return g2
+}
+
+// Subtract removes the counts expected from a background corpus, scaled
+// to the size of this corpus, dropping the terms left with nothing.
+func (w *WordFeq) Subtract(background *WordFeq) {
+	bgTotal := background.TotalTokens()
+	if bgTotal == 0 {
+		return
+	}
+	scale := float64(w.TotalTokens()) / float64(bgTotal)
+
+	for term, termCount := range w.terms {
+		bgCount, ok := background.terms[term]
+		if !ok {
+			continue
+		}
+
+		termCount -= int(math.Round(float64(bgCount) * scale))
+		if termCount <= 0 {
+			delete(w.terms, term)
+		} else {
+			w.terms[term] = termCount
+		}
+	}
+
+	w.stale = true
+}
+
+type TrendTerm struct {
+	Term   string
+	Before int
+	After  int
+	Growth float64 // ratio of the relative frequencies, smoothed so new terms are finite
+}
+
+type byGrowth []TrendTerm
+
+func (s byGrowth) Len() int {
+	return len(s)
+}
+func (s byGrowth) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+func (s byGrowth) Less(i, j int) bool {
+	t1 := s[i]
+	t2 := s[j]
+	if t1.Growth == t2.Growth {
+		return t1.Term < t2.Term
+	} else {
+		return t1.Growth > t2.Growth
+	}
+}
+
+// Trending ranks the terms by their relative growth from the before
+// state to the after one, e.g. two snapshots taken with Clone, and
+// returns the top n, or all of them if n <= 0.
+func Trending(before, after *WordFeq, n int) []TrendTerm {
+	b := float64(before.TotalTokens())
+	a := float64(after.TotalTokens())
+
+	list := make([]TrendTerm, 0, len(after.terms))
+	push := func(term string, x, y int) {
+		// add-one smoothing of both relative frequencies
+		growth := (float64(y) + 1) / (a + 1) / ((float64(x) + 1) / (b + 1))
+		list = append(list, TrendTerm{term, x, y, growth})
+	}
+	for term, termCount := range after.terms {
+		push(after.bareTerm(term), before.terms[term], termCount)
+	}
+	for term, termCount := range before.terms {
+		if _, ok := after.terms[term]; !ok {
+			push(before.bareTerm(term), termCount, 0)
+		}
+	}
+	sort.Sort(byGrowth(list))
+
+	if n > 0 && n < len(list) {
+		list = list[:n]
+	}
+	return list
+}
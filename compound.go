@@ -0,0 +1,75 @@
+package wordfreq
+
+import "strings"
+
+// Linking elements between the words of a German compound:
+// "Arbeitsamt", "Tageslicht", "Sonnenschein".
+var compoundLinks = []string{"s", "es", "n", "en"}
+
+// Append the words of the compound made of Options.CompoundLexicon words
+// only, each covering its part of the token at text[span[0]:span[1]]:
+// "Donaudampfschiff" -> "Donau", "dampf" and "schiff".
+func (w *WordFeq) appendLexiconParts(words []englishWord, text string, span []int, word string) []englishWord {
+	parts := splitCompound(w.lower(word), w.lexicon)
+	if len(parts) < 2 || len(w.lower(word)) != len(word) {
+		return words
+	}
+
+	start := span[0]
+	if i := strings.Index(text[span[0]:span[1]], word); i >= 0 {
+		start += i
+	}
+	for _, part := range parts {
+		words = append(words, englishWord{word[part[0]:part[1]], start + part[0], start + part[1], false, false})
+	}
+	return words
+}
+
+// Return the [start, end) byte offsets of the fewest lexicon words the
+// lower case word is made of, possibly joined by linking elements, or
+// nil if there are none.
+func splitCompound(word string, lexicon map[string]bool) [][]int {
+	n := len(word)
+
+	// parts[i] is the number of words of word[:i], from[i] and at[i]
+	// where its last word starts and ends
+	parts := make([]int, n+1)
+	from := make([]int, n+1)
+	at := make([]int, n+1)
+	for i := 1; i <= n; i++ {
+		parts[i] = -1
+	}
+
+	push := func(i, start, end int) {
+		if i <= n && (parts[i] < 0 || parts[start]+1 < parts[i]) {
+			parts[i] = parts[start] + 1
+			from[i] = start
+			at[i] = end
+		}
+	}
+	for i := 0; i < n; i++ {
+		if parts[i] < 0 {
+			continue
+		}
+		for j := i + 1; j <= n; j++ {
+			if !lexicon[word[i:j]] {
+				continue
+			}
+			push(j, i, j)
+			for _, link := range compoundLinks {
+				if j < n && strings.HasPrefix(word[j:], link) && j+len(link) < n {
+					push(j+len(link), i, j)
+				}
+			}
+		}
+	}
+	if parts[n] < 0 {
+		return nil
+	}
+
+	spans := make([][]int, parts[n])
+	for i, k := n, parts[n]-1; i > 0; i, k = from[i], k-1 {
+		spans[k] = []int{from[i], at[i]}
+	}
+	return spans
+}
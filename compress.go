@@ -0,0 +1,61 @@
+package wordfreq
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zipMagic  = []byte("PK\x03\x04")
+)
+
+// Return a reader of r decompressed if it is gzip compressed or a zip
+// archive, and whether it was. The entries of a zip archive are read one
+// after the other, the archive itself being read in memory first.
+func decompress(r io.Reader) (io.Reader, bool, error) {
+	br := bufio.NewReader(r)
+	head, err := br.Peek(len(zipMagic))
+	if err != nil && err != io.EOF {
+		return nil, false, err
+	}
+
+	switch {
+	case bytes.HasPrefix(head, gzipMagic):
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, false, err
+		}
+		return zr, true, nil
+
+	case bytes.HasPrefix(head, zipMagic):
+		data, err := io.ReadAll(br)
+		if err != nil {
+			return nil, false, err
+		}
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, false, err
+		}
+
+		readers := make([]io.Reader, 0, 2*len(zr.File))
+		for _, entry := range zr.File {
+			if entry.FileInfo().IsDir() {
+				continue
+			}
+			rc, err := entry.Open()
+			if err != nil {
+				return nil, false, err
+			}
+			// entries never share a line
+			readers = append(readers, rc, strings.NewReader("\n"))
+		}
+		return io.MultiReader(readers...), true, nil
+	}
+
+	return br, false, nil
+}
@@ -0,0 +1,141 @@
+package wordfreq
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// A single match of a term in the processed text, [start, end) in bytes.
+type occurrence struct {
+	term       string
+	start, end int
+}
+
+// Count the pairs of distinct terms found in the same sentence.
+func (w *WordFeq) countCooccurrences(text string, occurrences []occurrence, weight int) {
+	sentences := sentenceSpans(text)
+
+	terms := make([]map[string]bool, len(sentences))
+	for _, o := range occurrences {
+		// the sentence ending after the occurrence starts
+		i := sort.Search(len(sentences), func(i int) bool {
+			return sentences[i][1] > o.start
+		})
+		if i == len(sentences) {
+			continue
+		}
+
+		if terms[i] == nil {
+			terms[i] = make(map[string]bool)
+		}
+		terms[i][o.term] = true
+	}
+
+	for _, set := range terms {
+		for t1 := range set {
+			for t2 := range set {
+				if t1 == t2 {
+					continue
+				}
+
+				related, ok := w.cooccurrences[t1]
+				if !ok {
+					related = make(map[string]int)
+					w.cooccurrences[t1] = related
+				}
+				related[t2] += weight
+			}
+		}
+	}
+}
+
+// Cooccurrences returns the terms found in the same sentences as the
+// given term, with the number of sentences they share. Requires
+// Options.Cooccurrence.
+func (w *WordFeq) Cooccurrences(term string) []Term {
+	related := w.cooccurrences[term]
+	list := make([]Term, 0, len(related))
+	for t, n := range related {
+		list = append(list, w.newTerm(t, n))
+	}
+	sort.Sort(byTerm(list))
+	return list
+}
+
+// Each pair of co-occurring terms once, t1 < t2, in lexical order.
+func (w *WordFeq) cooccurrenceEdges(minWeight int) [][2]string {
+	edges := make([][2]string, 0)
+	for t1, related := range w.cooccurrences {
+		for t2, n := range related {
+			if t1 < t2 && n >= minWeight {
+				edges = append(edges, [2]string{t1, t2})
+			}
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i][0] == edges[j][0] {
+			return edges[i][1] < edges[j][1]
+		}
+		return edges[i][0] < edges[j][0]
+	})
+	return edges
+}
+
+// WriteDOT writes the co-occurrence graph in Graphviz DOT format,
+// skipping the edges shared by fewer than minWeight sentences.
+func (w *WordFeq) WriteDOT(out io.Writer, minWeight int) error {
+	if _, err := fmt.Fprintln(out, "graph cooccurrence {"); err != nil {
+		return err
+	}
+	for _, edge := range w.cooccurrenceEdges(minWeight) {
+		weight := w.cooccurrences[edge[0]][edge[1]]
+		if _, err := fmt.Fprintf(out, "\t%s -- %s [weight=%d];\n", strconv.Quote(edge[0]), strconv.Quote(edge[1]), weight); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(out, "}")
+	return err
+}
+
+// WriteGraphML writes the co-occurrence graph in GraphML format
+// (e.g. for Gephi), skipping the edges shared by fewer than minWeight
+// sentences.
+func (w *WordFeq) WriteGraphML(out io.Writer, minWeight int) error {
+	edges := w.cooccurrenceEdges(minWeight)
+
+	nodes := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, edge := range edges {
+		for _, term := range edge {
+			if !seen[term] {
+				seen[term] = true
+				nodes = append(nodes, term)
+			}
+		}
+	}
+	sort.Strings(nodes)
+
+	b := bufio.NewWriter(out)
+	b.WriteString(xml.Header)
+	b.WriteString("<graphml xmlns=\"http://graphml.graphdrawing.org/xmlns\">\n")
+	b.WriteString("\t<key id=\"weight\" for=\"edge\" attr.name=\"weight\" attr.type=\"int\"/>\n")
+	b.WriteString("\t<graph id=\"cooccurrence\" edgedefault=\"undirected\">\n")
+	for _, term := range nodes {
+		b.WriteString("\t\t<node id=\"")
+		xml.EscapeText(b, []byte(term))
+		b.WriteString("\"/>\n")
+	}
+	for _, edge := range edges {
+		b.WriteString("\t\t<edge source=\"")
+		xml.EscapeText(b, []byte(edge[0]))
+		b.WriteString("\" target=\"")
+		xml.EscapeText(b, []byte(edge[1]))
+		fmt.Fprintf(b, "\"><data key=\"weight\">%d</data></edge>\n", w.cooccurrences[edge[0]][edge[1]])
+	}
+	b.WriteString("\t</graph>\n</graphml>\n")
+	return b.Flush()
+}
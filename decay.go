@@ -0,0 +1,89 @@
+package wordfreq
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Exponentially decayed counts, with Options.HalfLife. A count added at
+// time t weighs 2^((t - landmark) / half life), so that adding does not
+// need to decay every other count; the weights are scaled down when
+// the landmark gets too old.
+type decayStats struct {
+	landmark time.Time
+	weights  map[string]float64
+}
+
+func newDecayStats() *decayStats {
+	return &decayStats{weights: make(map[string]float64)}
+}
+
+// The weight of a count at the time, relative to the landmark.
+func (d *decayStats) factor(at time.Time, halfLife time.Duration) float64 {
+	return math.Exp2(float64(at.Sub(d.landmark)) / float64(halfLife))
+}
+
+func (d *decayStats) add(term string, count int, at time.Time, halfLife time.Duration) {
+	if d.landmark.IsZero() {
+		d.landmark = at
+	}
+
+	// keep the weights far from overflowing
+	if at.Sub(d.landmark) > 64*halfLife {
+		scale := 1 / d.factor(at, halfLife)
+		for t := range d.weights {
+			d.weights[t] *= scale
+		}
+		d.landmark = at
+	}
+
+	d.weights[term] += float64(count) * d.factor(at, halfLife)
+}
+
+func (d *decayStats) merge(s *decayStats, halfLife time.Duration) {
+	if s.landmark.IsZero() {
+		return
+	}
+	if d.landmark.IsZero() {
+		d.landmark = s.landmark
+	}
+
+	scale := d.factor(s.landmark, halfLife)
+	for term, weight := range s.weights {
+		d.weights[term] += weight * scale
+	}
+}
+
+// ProcessAt is like Process, for a text of the given time when counts
+// decay with Options.HalfLife; Process uses the current time.
+func (w *WordFeq) ProcessAt(text string, at time.Time) []Term {
+	w.at = at
+	defer func() {
+		w.at = time.Time{}
+	}()
+
+	return w.Process(text)
+}
+
+// HotTerms returns the top n terms, or all of them if n <= 0, by their
+// counts decayed to the given time, the currently hot terms first.
+// Requires Options.HalfLife.
+func (w *WordFeq) HotTerms(n int, now time.Time) []ScoredTerm {
+	d := w.decay
+	terms := make([]ScoredTerm, 0, len(d.weights))
+	if d.landmark.IsZero() {
+		return terms
+	}
+
+	scale := 1 / d.factor(now, w.options.HalfLife)
+	for key, weight := range d.weights {
+		terms = append(terms, ScoredTerm{w.newTerm(key, 0).Term, weight * scale})
+	}
+	sort.Sort(byScore(terms))
+
+	if n > 0 && n < len(terms) {
+		terms = terms[:n]
+	}
+	return terms
+}
@@ -0,0 +1,20 @@
+package wordfreq
+
+// Delta returns the listed terms whose counts changed with the last
+// processed text, in the order of the list, for Options.RecordDelta.
+// The terms still below MinimumCount, or filtered out as substrings, are
+// left out as in the list.
+func (w *WordFeq) Delta() []Term {
+	w.sync()
+
+	delta := make([]Term, 0, len(w.delta))
+	if len(w.delta) == 0 {
+		return delta
+	}
+	for _, t := range w.list {
+		if _, ok := w.delta[w.termKey(t)]; ok {
+			delta = append(delta, t)
+		}
+	}
+	return delta
+}
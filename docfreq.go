@@ -0,0 +1,77 @@
+package wordfreq
+
+import (
+	"sort"
+)
+
+// Count the terms found in the document that just ended.
+func (w *WordFeq) endDocument() {
+	w.inDocument = false
+	if !w.options.DocumentFrequency && !w.options.DocumentVectors {
+		return
+	}
+
+	if w.options.DocumentFrequency {
+		for term := range w.docTerms {
+			w.docFreq[term]++
+		}
+	}
+	if w.options.DocumentVectors {
+		w.vectors = append(w.vectors, w.docTerms)
+	}
+	w.docTerms = make(map[string]int)
+	w.dfDocuments++
+}
+
+// DocumentFrequency returns the number of documents the term was found
+// in. Requires Options.DocumentFrequency.
+func (w *WordFeq) DocumentFrequency(term string) int {
+	return w.docFreq[term]
+}
+
+// Documents returns the number of documents processed with
+// Options.DocumentFrequency or Options.DocumentVectors.
+func (w *WordFeq) Documents() int {
+	return w.dfDocuments
+}
+
+// DocumentVector returns the counts of the terms of a document, numbered
+// from 0 in processing order up to Documents(). Requires
+// Options.DocumentVectors.
+func (w *WordFeq) DocumentVector(doc int) map[string]int {
+	vector := make(map[string]int)
+	if doc < 0 || doc >= len(w.vectors) {
+		return vector
+	}
+	for term, n := range w.vectors[doc] {
+		vector[term] = n
+	}
+	return vector
+}
+
+// SuggestStopWords returns the terms found in at least the threshold
+// ratio of the documents, e.g. 0.9, as candidates for Options.StopWords,
+// most widespread first. Requires Options.DocumentFrequency.
+func (w *WordFeq) SuggestStopWords(threshold float64) []string {
+	if w.dfDocuments == 0 {
+		return []string{}
+	}
+
+	candidates := make([]Term, 0)
+	for key, n := range w.docFreq {
+		if float64(n)/float64(w.dfDocuments) >= threshold {
+			candidates = append(candidates, w.newTerm(key, n))
+		}
+	}
+	sort.Sort(byTerm(candidates))
+
+	words := make([]string, 0, len(candidates))
+	seen := make(map[string]bool)
+	for _, t := range candidates {
+		if !seen[t.Term] {
+			seen[t.Term] = true
+			words = append(words, t.Term)
+		}
+	}
+	return words
+}
@@ -0,0 +1,82 @@
+package wordfreq
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Encoder writes the results of a WordFeq in an output format.
+type Encoder interface {
+	Encode(out io.Writer, w *WordFeq) error
+}
+
+// EncoderFunc adapts a function to an Encoder.
+type EncoderFunc func(out io.Writer, w *WordFeq) error
+
+func (f EncoderFunc) Encode(out io.Writer, w *WordFeq) error {
+	return f(out, w)
+}
+
+var (
+	encodersMu sync.RWMutex
+	encoders   = map[string]Encoder{
+		"tsv": EncoderFunc(writeTSV),
+		"ndjson": EncoderFunc(func(out io.Writer, w *WordFeq) error {
+			return w.WriteNDJSON(out)
+		}),
+		"html": EncoderFunc(func(out io.Writer, w *WordFeq) error {
+			return w.WriteHTMLReport(out, ReportOptions{})
+		}),
+		"dot": EncoderFunc(func(out io.Writer, w *WordFeq) error {
+			return w.WriteDOT(out, 1)
+		}),
+		"graphml": EncoderFunc(func(out io.Writer, w *WordFeq) error {
+			return w.WriteGraphML(out, 1)
+		}),
+	}
+)
+
+// RegisterEncoder makes the encoder available by name, e.g. to the
+// --format flag of the command, replacing the encoder of the same name.
+// The built-in encoders are tsv, ndjson, html, dot and graphml.
+func RegisterEncoder(name string, e Encoder) {
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+	encoders[name] = e
+}
+
+// LookupEncoder returns the encoder registered by the name.
+func LookupEncoder(name string) (Encoder, bool) {
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+	e, ok := encoders[name]
+	return e, ok
+}
+
+// EncoderNames returns the names of the registered encoders, sorted.
+func EncoderNames() []string {
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+	names := make([]string, 0, len(encoders))
+	for name := range encoders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// The sorted list, a term and its count per line, separated by a tab.
+func writeTSV(out io.Writer, w *WordFeq) error {
+	w.sync()
+
+	b := bufio.NewWriter(out)
+	for _, t := range w.list {
+		if _, err := fmt.Fprintf(b, "%s\t%d\n", t.Term, t.Count); err != nil {
+			return err
+		}
+	}
+	return b.Flush()
+}
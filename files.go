@@ -0,0 +1,187 @@
+package wordfreq
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+// ProcessFiles processes the files matching the glob patterns, walking
+// the directories matched, each file being a document. Gzip compressed
+// files are decompressed, and every entry of a zip archive is a
+// document. Binary files are skipped.
+func (w *WordFeq) ProcessFiles(patterns ...string) ([]Term, error) {
+	paths, err := expandPatterns(patterns)
+	if err != nil {
+		return w.List(), err
+	}
+
+	for _, path := range paths {
+		if err := w.processFile(path); err != nil {
+			return w.List(), err
+		}
+	}
+	return w.List(), nil
+}
+
+// Return the regular files matching the patterns, in lexical order
+// and without duplicates.
+func expandPatterns(patterns []string) ([]string, error) {
+	seen := make(map[string]bool)
+	paths := make([]string, 0)
+	push := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		if matches == nil {
+			// a path the pattern syntax does not match, e.g. with brackets
+			if _, err := os.Stat(pattern); err != nil {
+				return nil, err
+			}
+			matches = []string{pattern}
+		}
+		sort.Strings(matches)
+
+		for _, match := range matches {
+			match = filepath.Clean(match)
+			info, err := os.Stat(match)
+			if err != nil {
+				return nil, err
+			}
+			if !info.IsDir() {
+				push(match)
+				continue
+			}
+
+			// WalkDir visits in lexical order already
+			err = filepath.WalkDir(match, func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if d.Type().IsRegular() {
+					push(path)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return paths, nil
+}
+
+func (w *WordFeq) processFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	total := readerSize(f)
+	head := make([]byte, len(zipMagic))
+	n, _ := io.ReadFull(f, head)
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	// every entry of a zip archive is a document
+	if bytes.HasPrefix(head[:n], zipMagic) {
+		zr, err := zip.NewReader(f, total)
+		if err != nil {
+			return err
+		}
+		for _, entry := range zr.File {
+			if entry.FileInfo().IsDir() {
+				continue
+			}
+			if err := w.processZipEntry(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	r, compressed, err := decompress(f)
+	if err != nil {
+		return err
+	}
+	if compressed {
+		total = -1
+	}
+	return w.processText(r, total)
+}
+
+func (w *WordFeq) processZipEntry(entry *zip.File) error {
+	rc, err := entry.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	return w.processText(rc, int64(entry.UncompressedSize64))
+}
+
+// Process r as a document if it is text.
+func (w *WordFeq) processText(r io.Reader, total int64) error {
+	r, err := textReader(r, w.options.Charset)
+	if err != nil || r == nil {
+		return err
+	}
+	return w.processReader(r, total)
+}
+
+// Return a reader of the text in r as UTF-8, using its byte order mark
+// or else the charset, or nil if r looks binary.
+func textReader(r io.Reader, charset string) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	head, err := br.Peek(512)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, err
+	}
+
+	switch {
+	case bytes.HasPrefix(head, []byte{0xEF, 0xBB, 0xBF}):
+		br.Discard(3)
+		return br, nil
+	case bytes.HasPrefix(head, []byte{0xFF, 0xFE}):
+		return decodeUTF16(br, false)
+	case bytes.HasPrefix(head, []byte{0xFE, 0xFF}):
+		return decodeUTF16(br, true)
+	case bytes.IndexByte(head, 0) >= 0:
+		return nil, nil
+	}
+	return transcode(br, charset)
+}
+
+func decodeUTF16(r io.Reader, bigEndian bool) (io.Reader, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	units := make([]uint16, 0, len(data)/2)
+	for i := 2; i+1 < len(data); i += 2 {
+		if bigEndian {
+			units = append(units, uint16(data[i])<<8|uint16(data[i+1]))
+		} else {
+			units = append(units, uint16(data[i+1])<<8|uint16(data[i]))
+		}
+	}
+	return strings.NewReader(string(utf16.Decode(units))), nil
+}
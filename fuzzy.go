@@ -0,0 +1,103 @@
+package wordfreq
+
+import (
+	"sort"
+	"unicode/utf8"
+)
+
+// MergeSimilar merges every English term within maxDistance edits of a
+// more frequent one into it, e.g. the typos and OCR errors of noisy text,
+// and returns the merged terms mapped to the terms they were merged into.
+// Terms of up to 3 runes per allowed edit are left alone, "cat" is not a
+// typo of "car".
+func (w *WordFeq) MergeSimilar(maxDistance int) map[string]string {
+	merged := make(map[string]string)
+	if maxDistance <= 0 {
+		return merged
+	}
+
+	type candidate struct {
+		term  string
+		runes []rune
+	}
+	candidates := make([]candidate, 0)
+	for term := range w.terms {
+		if w.languages[term] != "english" {
+			continue
+		}
+		// without the language of Options.SeparateLanguages
+		word := w.bareTerm(term)
+		if utf8.RuneCountInString(word) > 3*maxDistance {
+			candidates = append(candidates, candidate{term, []rune(word)})
+		}
+	}
+
+	// the most frequent terms first, the others merged into them
+	sort.Slice(candidates, func(i, j int) bool {
+		c1, c2 := w.terms[candidates[i].term], w.terms[candidates[j].term]
+		if c1 == c2 {
+			return candidates[i].term < candidates[j].term
+		}
+		return c1 > c2
+	})
+
+	kept := make([]candidate, 0)
+	for _, c := range candidates {
+		into := ""
+		for _, k := range kept {
+			if editDistance(k.runes, c.runes, maxDistance) <= maxDistance {
+				into = k.term
+				break
+			}
+		}
+		if into == "" {
+			kept = append(kept, c)
+			continue
+		}
+		merged[c.term] = into
+	}
+
+	for src, dst := range merged {
+		w.MergeTerms(dst, src)
+	}
+	return merged
+}
+
+// Levenshtein distance between a and b, or max+1 once known to be
+// greater than max.
+func editDistance(a, b []rune, max int) int {
+	if len(a)-len(b) > max || len(b)-len(a) > max {
+		return max + 1
+	}
+
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		least := curr[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j-1] + cost
+			if prev[j]+1 < curr[j] {
+				curr[j] = prev[j] + 1
+			}
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+			if curr[j] < least {
+				least = curr[j]
+			}
+		}
+		if least > max {
+			return max + 1
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,124 @@
+package wordfreq
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type ScoredTerm struct {
+	Term  string
+	Score float64
+}
+
+type byScore []ScoredTerm
+
+func (s byScore) Len() int {
+	return len(s)
+}
+func (s byScore) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+func (s byScore) Less(i, j int) bool {
+	t1 := s[i]
+	t2 := s[j]
+	if t1.Score == t2.Score {
+		return t1.Term < t2.Term
+	} else {
+		return t1.Score > t2.Score
+	}
+}
+
+// RAKE (Rapid Automatic Keyword Extraction) statistics, accumulated
+// across Process calls.
+type rakeStats struct {
+	phrases map[string]int // candidate phrase -> count
+	freq    map[string]int // word -> count in candidate phrases
+	degree  map[string]int // word -> sum of the lengths of its phrases
+}
+
+func newRakeStats() *rakeStats {
+	return &rakeStats{
+		phrases: make(map[string]int),
+		freq:    make(map[string]int),
+		degree:  make(map[string]int),
+	}
+}
+
+var (
+	// anything that is neither a word character nor a plain space
+	// ends a candidate phrase
+	rakeDelimiters = regexp.MustCompile("[^A-Za-zéÉ'’_\\-0-9@ \t]+")
+)
+
+func (r *rakeStats) process(text string, stopWords []string, weight int) {
+	for _, fragment := range rakeDelimiters.Split(text, -1) {
+		phrase := make([]string, 0)
+		for _, word := range strings.Fields(fragment) {
+			word = dropContractions(word)
+			word = strings.ToLower(word)
+
+			// stop words and numbers end the current phrase
+			if word == "" || engTest.MatchString(word) || isStopWord(word, stopWords) {
+				r.push(phrase, weight)
+				phrase = phrase[:0]
+				continue
+			}
+
+			phrase = append(phrase, word)
+		}
+		r.push(phrase, weight)
+	}
+}
+
+func (r *rakeStats) push(phrase []string, weight int) {
+	if len(phrase) == 0 {
+		return
+	}
+
+	r.phrases[strings.Join(phrase, " ")] += weight
+	for _, word := range phrase {
+		r.freq[word] += weight
+		r.degree[word] += len(phrase) * weight
+	}
+}
+
+func isStopWord(word string, stopWords []string) bool {
+	for _, stopWord := range stopWords {
+		if stopWord == word {
+			return true
+		}
+	}
+	return false
+}
+
+// Like isStopWord, ignoring the case.
+func isStopWordFold(word string, stopWords []string) bool {
+	for _, stopWord := range stopWords {
+		if strings.EqualFold(stopWord, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// Keywords returns the top n candidate phrases ranked by RAKE score,
+// or all of them if n <= 0. Requires Options.ExtractKeywords.
+func (w *WordFeq) Keywords(n int) []ScoredTerm {
+	r := w.rake
+	keywords := make([]ScoredTerm, 0, len(r.phrases))
+	for phrase := range r.phrases {
+		// phrase score is the sum of its word scores (degree / frequency)
+		score := 0.0
+		for _, word := range strings.Split(phrase, " ") {
+			score += float64(r.degree[word]) / float64(r.freq[word])
+		}
+		keywords = append(keywords, ScoredTerm{phrase, score})
+	}
+	sort.Sort(byScore(keywords))
+
+	if n > 0 && n < len(keywords) {
+		keywords = keywords[:n]
+	}
+	return keywords
+}
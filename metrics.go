@@ -0,0 +1,18 @@
+package wordfreq
+
+import "time"
+
+// Metrics receives the instrumentation of the processing, e.g. to feed
+// Prometheus counters, histograms and gauges. Its methods may be called
+// from several goroutines at once by ProcessAll.
+type Metrics interface {
+	ObserveText(bytes int, elapsed time.Duration) // after each processed text
+	SetTerms(n int)                               // number of distinct terms counted
+}
+
+// The Metrics of a shard, its terms being reported once merged.
+type shardMetrics struct {
+	Metrics
+}
+
+func (shardMetrics) SetTerms(n int) {}
@@ -0,0 +1,27 @@
+package wordfreq
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+// WriteNDJSON writes the sorted list as newline-delimited JSON, one
+// {"term":...,"count":...} object per line, e.g. for jq or BigQuery.
+func (w *WordFeq) WriteNDJSON(out io.Writer) error {
+	w.sync()
+
+	b := bufio.NewWriter(out)
+	enc := json.NewEncoder(b)
+	enc.SetEscapeHTML(false)
+	for _, t := range w.list {
+		line := struct {
+			Term  string `json:"term"`
+			Count int    `json:"count"`
+		}{t.Term, t.Count}
+		if err := enc.Encode(line); err != nil {
+			return err
+		}
+	}
+	return b.Flush()
+}
@@ -0,0 +1,68 @@
+package wordfreq
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Span locates an occurrence of a term: the [Start, End) byte offsets
+// in the Document-th processed text, as it was given, before the
+// invisible characters are dropped and the other rewrites of the options
+// like FoldWidth or Dehyphenate.
+type Span struct {
+	Document int
+	Start    int
+	End      int
+}
+
+func (w *WordFeq) recordOccurrences(occurrences []occurrence, offsets *textOffsets) {
+	for _, o := range occurrences {
+		start, end := offsets.span(o.start, o.end)
+		w.occurrences[o.term] = append(w.occurrences[o.term], Span{w.documents, start, end})
+	}
+}
+
+// Occurrences returns where the term was found, in order of appearance.
+// Requires Options.RecordOccurrences.
+func (w *WordFeq) Occurrences(term string) []Span {
+	spans := append([]Span(nil), w.occurrences[term]...)
+	sort.Slice(spans, func(i, j int) bool {
+		if spans[i].Document == spans[j].Document {
+			return spans[i].Start < spans[j].Start
+		}
+		return spans[i].Document < spans[j].Document
+	})
+	return spans
+}
+
+var whitespaces = strings.NewReplacer("\n", " ", "\r", " ", "\t", " ")
+
+// Concordance returns a keyword-in-context line for each occurrence of
+// the term: up to window runes of left context, the term and up to
+// window runes of right context, separated by tabs.
+// Requires Options.RecordOccurrences.
+func (w *WordFeq) Concordance(term string, window int) []string {
+	spans := w.Occurrences(term)
+	lines := make([]string, 0, len(spans))
+	for _, span := range spans {
+		text := w.texts[span.Document]
+
+		start := span.Start
+		for n := 0; n < window && start > 0; n++ {
+			_, size := utf8.DecodeLastRuneInString(text[:start])
+			start -= size
+		}
+
+		end := span.End
+		for n := 0; n < window && end < len(text); n++ {
+			_, size := utf8.DecodeRuneInString(text[end:])
+			end += size
+		}
+
+		lines = append(lines, whitespaces.Replace(text[start:span.Start])+"\t"+
+			text[span.Start:span.End]+"\t"+
+			whitespaces.Replace(text[span.End:end]))
+	}
+	return lines
+}
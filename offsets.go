@@ -0,0 +1,121 @@
+package wordfreq
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/width"
+)
+
+// Replacement of text[start:end] by prepare.
+type edit struct {
+	start       int
+	end         int
+	replacement string
+}
+
+// Byte offsets of a prepared text in the text given to process, nil if
+// prepare left it as it was: starts[i] is where byte i comes from, and
+// ends[i] where the bytes before i end.
+type textOffsets struct {
+	starts []int
+	ends   []int
+}
+
+// The offsets a span of the prepared text comes from.
+func (o *textOffsets) span(start, end int) (int, int) {
+	if o == nil {
+		return start, end
+	}
+	return o.starts[start], o.ends[end]
+}
+
+// Apply the edits, in order and not overlapping, to the text, along with
+// its offsets.
+func rewrite(text string, offsets *textOffsets, edits []edit) (string, *textOffsets) {
+	if len(edits) == 0 {
+		return text, offsets
+	}
+
+	origin := func(i int) (int, int) {
+		return offsets.span(i, i)
+	}
+	rewritten := &textOffsets{
+		starts: make([]int, 0, len(text)+1),
+		ends:   make([]int, 1, len(text)+1),
+	}
+	_, rewritten.ends[0] = origin(0)
+
+	var b strings.Builder
+	b.Grow(len(text))
+	keep := func(from, to int) {
+		for i := from; i < to; i++ {
+			start, _ := origin(i)
+			_, end := origin(i + 1)
+			rewritten.starts = append(rewritten.starts, start)
+			rewritten.ends = append(rewritten.ends, end)
+		}
+		b.WriteString(text[from:to])
+	}
+
+	last := 0
+	for _, e := range edits {
+		keep(last, e.start)
+
+		// the replacement spans all of the replaced bytes
+		start, _ := origin(e.start)
+		_, end := origin(e.end)
+		for i := 0; i < len(e.replacement); i++ {
+			rewritten.starts = append(rewritten.starts, start)
+			rewritten.ends = append(rewritten.ends, end)
+		}
+		b.WriteString(e.replacement)
+		last = e.end
+	}
+	keep(last, len(text))
+
+	start, _ := origin(len(text))
+	rewritten.starts = append(rewritten.starts, start)
+
+	return b.String(), rewritten
+}
+
+// Edits replacing each run of invalid UTF-8 bytes, as
+// strings.ToValidUTF8 does.
+func invalidUTF8Edits(text, replacement string) []edit {
+	edits := make([]edit, 0)
+	for i := 0; i < len(text); {
+		if r, size := utf8.DecodeRuneInString(text[i:]); r != utf8.RuneError || size != 1 {
+			i += size
+			continue
+		}
+
+		start := i
+		for i < len(text) {
+			r, size := utf8.DecodeRuneInString(text[i:])
+			if r != utf8.RuneError || size != 1 {
+				break
+			}
+			i += size
+		}
+		edits = append(edits, edit{start, i, replacement})
+	}
+	return edits
+}
+
+// Edits replacing the runes f returns true for.
+func runeEdits(text string, f func(r rune) (string, bool)) []edit {
+	edits := make([]edit, 0)
+	for i, r := range text {
+		if replacement, ok := f(r); ok {
+			edits = append(edits, edit{i, i + utf8.RuneLen(r), replacement})
+		}
+	}
+	return edits
+}
+
+// Fold the width of a rune, as width.Fold does rune by rune.
+func foldWidth(r rune) (string, bool) {
+	folded := width.Fold.String(string(r))
+	return folded, folded != string(r)
+}
@@ -0,0 +1,191 @@
+package wordfreq
+
+import (
+	"context"
+	"sync"
+)
+
+// ProcessAll processes the texts received from the channel with workers
+// goroutines, until the channel is closed or the context is done, and
+// merges their counts. Texts are numbered in order of merging, not of
+// receiving, for Occurrences.
+func (w *WordFeq) ProcessAll(ctx context.Context, texts <-chan string, workers int) error {
+	if workers <= 0 {
+		workers = 1
+	}
+
+	shards := make([]*WordFeq, workers)
+	var wg sync.WaitGroup
+	for i := range shards {
+		shards[i] = w.shard()
+		wg.Add(1)
+		go func(s *WordFeq) {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case text, ok := <-texts:
+					if !ok {
+						return
+					}
+					s.process(text, 1)
+				}
+			}
+		}(shards[i])
+	}
+	wg.Wait()
+
+	for _, s := range shards {
+		w.merge(s)
+	}
+	w.stale = true
+
+	return ctx.Err()
+}
+
+// An empty WordFeq with the same configuration.
+func (w *WordFeq) shard() *WordFeq {
+	s := &WordFeq{
+		options:       w.options,
+		engSplit:      w.engSplit,
+		chSplit:       w.chSplit,
+		stems:         newStemCache(w.options.StemCacheSize),
+		vocabulary:    w.vocabulary,
+		stopWords:     w.stopWords,
+		abbreviations: w.abbreviations,
+		lexicon:       w.lexicon,
+		spellings:     w.spellings,
+	}
+	s.lower, _ = newLower(w.options.Locale)
+	s.collator, _ = newCollator(w.options.CollationLocale)
+	if w.options.Metrics != nil {
+		s.options.Metrics = shardMetrics{w.options.Metrics}
+	}
+	s.Empty()
+	return s
+}
+
+// Add the counts of a shard.
+func (w *WordFeq) merge(s *WordFeq) {
+	var before map[string]int
+	if w.watching() {
+		before = make(map[string]int)
+		defer func() { w.notify(before) }()
+	}
+	if w.options.Metrics != nil {
+		defer func() { w.options.Metrics.SetTerms(len(w.terms)) }()
+	}
+	for term, n := range s.terms {
+		if before != nil {
+			before[term] = w.terms[term]
+		}
+		w.terms[term] += n
+	}
+
+	for term, lang := range s.languages {
+		w.languages[term] = lang
+	}
+
+	for term, forms := range s.variants {
+		if w.variants[term] == nil {
+			w.variants[term] = make(map[string]int)
+		}
+		for word, n := range forms {
+			w.variants[term][word] += n
+		}
+	}
+
+	for ngram, n := range s.ngrams {
+		w.ngrams[ngram] += n
+	}
+
+	for pair, n := range s.skipGrams {
+		w.skipGrams[pair] += n
+	}
+
+	for term, substrings := range s.absorbed {
+		if w.absorbed[term] == nil {
+			w.absorbed[term] = make(map[string]int)
+		}
+		for substring, n := range substrings {
+			w.absorbed[term][substring] += n
+		}
+	}
+
+	for term, related := range s.cooccurrences {
+		if w.cooccurrences[term] == nil {
+			w.cooccurrences[term] = make(map[string]int)
+		}
+		for t, n := range related {
+			w.cooccurrences[term][t] += n
+		}
+	}
+
+	// renumber the texts of the shard after ours
+	for term, seen := range s.seen {
+		seen.First += w.documents
+		seen.Last += w.documents
+		if old, ok := w.seen[term]; ok {
+			seen = old.union(seen)
+		}
+		w.seen[term] = seen
+	}
+	for term, spans := range s.occurrences {
+		for _, span := range spans {
+			span.Document += w.documents
+			w.occurrences[term] = append(w.occurrences[term], span)
+		}
+	}
+	w.texts = append(w.texts, s.texts...)
+	w.documents += s.documents
+
+	for term, n := range s.docFreq {
+		w.docFreq[term] += n
+	}
+	w.dfDocuments += s.dfDocuments
+	for _, vector := range s.vectors {
+		copied := make(map[string]int, len(vector))
+		for term, n := range vector {
+			copied[term] = n
+		}
+		w.vectors = append(w.vectors, copied)
+	}
+
+	w.filtered.StopWords += s.filtered.StopWords
+	w.filtered.Length += s.filtered.Length
+	w.filtered.Numbers += s.filtered.Numbers
+	w.filtered.Vocabulary += s.filtered.Vocabulary
+
+	w.decay.merge(s.decay, w.options.HalfLife)
+
+	for phrase, n := range s.rake.phrases {
+		w.rake.phrases[phrase] += n
+	}
+	for word, n := range s.rake.freq {
+		w.rake.freq[word] += n
+	}
+	for word, n := range s.rake.degree {
+		w.rake.degree[word] += n
+	}
+}
+
+// Clone returns a deep copy of the counter, e.g. to report on a snapshot
+// while the processing goes on. A document being read by ProcessReader
+// is left out of the document frequencies of the copy.
+func (w *WordFeq) Clone() *WordFeq {
+	c := w.shard()
+	c.merge(w)
+
+	for _, t := range w.list {
+		if t.Absorbed != nil {
+			t.Absorbed = append([]string(nil), t.Absorbed...)
+		}
+		c.list = append(c.list, t)
+	}
+	for term, rank := range w.ranks {
+		c.ranks[term] = rank
+	}
+	c.stale = w.stale
+	return c
+}
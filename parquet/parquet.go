@@ -0,0 +1,41 @@
+// Package parquet writes the list of a WordFeq as Parquet files, for
+// columnar analytics tools such as pandas or Spark.
+package parquet
+
+import (
+	"io"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/twsiyuan/wordfreq"
+)
+
+// Row of the Parquet file, one per listed term.
+type Row struct {
+	Term      string  `parquet:"term"`
+	Count     int64   `parquet:"count"`
+	Frequency float64 `parquet:"frequency"` // Count / TotalTokens()
+	Rank      int32   `parquet:"rank"`
+	Language  string  `parquet:"language"`
+}
+
+// Export writes the listed terms of w, most frequent first, with their
+// term, count, frequency, rank and language columns.
+func Export(w *wordfreq.WordFeq, out io.Writer) error {
+	list := w.SortedList(wordfreq.SortByCountDesc)
+	total := float64(w.TotalTokens())
+
+	rows := make([]Row, 0, len(list))
+	for _, t := range list {
+		row := Row{Term: t.Term, Count: int64(t.Count), Rank: int32(t.Rank), Language: t.Language}
+		if total > 0 {
+			row.Frequency = float64(t.Count) / total
+		}
+		rows = append(rows, row)
+	}
+
+	writer := parquet.NewGenericWriter[Row](out)
+	if _, err := writer.Write(rows); err != nil {
+		return err
+	}
+	return writer.Close()
+}
@@ -0,0 +1,152 @@
+package wordfreq
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Header of the Save format, followed by its version.
+const (
+	saveMagic   = "WFQ"
+	saveVersion = 1
+
+	// no term is that long, the data is broken
+	maxSavedString = 1 << 20
+
+	// the largest count, not overflowing an int
+	maxSavedCount = int(^uint(0) >> 1)
+)
+
+// Save writes the counts of all terms, with the languages they were found
+// in, in a compact binary format read by Load:
+//
+//	"WFQ", version byte
+//	uvarint number of languages, then each as a string
+//	uvarint number of terms, then each as a string, its count as a
+//	uvarint and the index of its language as a uvarint
+//
+// where a string is its uvarint length followed by its bytes.
+func (w *WordFeq) Save(out io.Writer) error {
+	b := bufio.NewWriter(out)
+	b.WriteString(saveMagic)
+	b.WriteByte(saveVersion)
+
+	var buf [binary.MaxVarintLen64]byte
+	putUvarint := func(x uint64) {
+		n := binary.PutUvarint(buf[:], x)
+		b.Write(buf[:n])
+	}
+	putString := func(s string) {
+		putUvarint(uint64(len(s)))
+		b.WriteString(s)
+	}
+
+	terms := make([]string, 0, len(w.terms))
+	for term := range w.terms {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+
+	// the languages once, "" for AddTerm, in the order of the terms
+	languages := make(map[string]int)
+	names := make([]string, 0)
+	for _, term := range terms {
+		lang := w.languages[term]
+		if _, ok := languages[lang]; !ok {
+			languages[lang] = len(names)
+			names = append(names, lang)
+		}
+	}
+
+	putUvarint(uint64(len(names)))
+	for _, name := range names {
+		putString(name)
+	}
+	putUvarint(uint64(len(terms)))
+	for _, term := range terms {
+		putString(term)
+		putUvarint(uint64(w.terms[term]))
+		putUvarint(uint64(languages[w.languages[term]]))
+	}
+
+	return b.Flush()
+}
+
+// Load adds the counts written by Save to the counted terms.
+func (w *WordFeq) Load(in io.Reader) error {
+	b := bufio.NewReader(in)
+
+	header := make([]byte, len(saveMagic)+1)
+	if _, err := io.ReadFull(b, header); err != nil {
+		return err
+	}
+	if string(header[:len(saveMagic)]) != saveMagic {
+		return errors.New("wordfreq: not a saved WordFeq")
+	}
+	if version := header[len(saveMagic)]; version != saveVersion {
+		return fmt.Errorf("wordfreq: unknown save format version %d", version)
+	}
+
+	getString := func() (string, error) {
+		n, err := binary.ReadUvarint(b)
+		if err != nil {
+			return "", err
+		}
+		if n > maxSavedString {
+			return "", fmt.Errorf("wordfreq: saved string of %d bytes", n)
+		}
+		s := make([]byte, n)
+		_, err = io.ReadFull(b, s)
+		return string(s), err
+	}
+
+	n, err := binary.ReadUvarint(b)
+	if err != nil {
+		return err
+	}
+	// appended as read, n is not trusted to allocate
+	names := make([]string, 0)
+	for i := uint64(0); i < n; i++ {
+		name, err := getString()
+		if err != nil {
+			return err
+		}
+		names = append(names, name)
+	}
+
+	if n, err = binary.ReadUvarint(b); err != nil {
+		return err
+	}
+	for i := uint64(0); i < n; i++ {
+		term, err := getString()
+		if err != nil {
+			return err
+		}
+		count, err := binary.ReadUvarint(b)
+		if err != nil {
+			return err
+		}
+		if count > uint64(maxSavedCount-w.terms[term]) {
+			return fmt.Errorf("wordfreq: saved count %d of %q overflows int", count, term)
+		}
+		lang, err := binary.ReadUvarint(b)
+		if err != nil {
+			return err
+		}
+		if lang >= uint64(len(names)) {
+			return fmt.Errorf("wordfreq: unknown language index %d of %q", lang, term)
+		}
+
+		w.terms[term] += int(count)
+		if _, ok := w.languages[term]; !ok && names[lang] != "" {
+			w.languages[term] = names[lang]
+		}
+	}
+	w.stale = true
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package wordfreq
+
+import (
+	"math"
+	"sort"
+	"unicode/utf8"
+)
+
+// PhraseScores ranks the Chinese phrases by how much they look like
+// words rather than by count, and returns the top n, or all of them if
+// n <= 0. The score of a phrase is its cohesion, the least pointwise
+// mutual information of its two parts however it is cut, times its
+// boundary entropy, the least entropy of the characters found before
+// and after it. Requires Options.RecordNGrams, the phrases being of up
+// to MaxiumPhraseLength-1 characters, found at least MinimumCount times.
+func (w *WordFeq) PhraseScores(n int) []ScoredTerm {
+	_, maxLength := w.options.termLengths("chinese")
+	scores := phraseScores(w.ngrams, w.options.MinimumCount, maxLength)
+	if n > 0 && n < len(scores) {
+		scores = scores[:n]
+	}
+	return scores
+}
+
+func phraseScores(ngrams map[string]int, minCount, maxLength int) []ScoredTerm {
+	// the characters counted, and those found before and after each
+	// N-gram
+	total := 0
+	before := make(map[string]map[rune]int)
+	after := make(map[string]map[rune]int)
+	push := func(neighbors map[string]map[rune]int, ngram string, r rune, count int) {
+		if neighbors[ngram] == nil {
+			neighbors[ngram] = make(map[rune]int)
+		}
+		neighbors[ngram][r] += count
+	}
+	for ngram, count := range ngrams {
+		if utf8.RuneCountInString(ngram) == 1 {
+			total += count
+			continue
+		}
+		first, size := utf8.DecodeRuneInString(ngram)
+		last, lastSize := utf8.DecodeLastRuneInString(ngram)
+		push(before, ngram[size:], first, count)
+		push(after, ngram[:len(ngram)-lastSize], last, count)
+	}
+	if total == 0 {
+		return []ScoredTerm{}
+	}
+
+	scores := make([]ScoredTerm, 0)
+	for ngram, count := range ngrams {
+		length := utf8.RuneCountInString(ngram)
+		if length < 2 || length >= maxLength || count < minCount {
+			continue
+		}
+
+		cohesion := math.Inf(1)
+		for i := range ngram {
+			if i == 0 {
+				continue
+			}
+			a, b := ngrams[ngram[:i]], ngrams[ngram[i:]]
+			if pmi := math.Log(float64(count) * float64(total) / (float64(a) * float64(b))); pmi < cohesion {
+				cohesion = pmi
+			}
+		}
+
+		entropy := math.Min(boundaryEntropy(before[ngram], count), boundaryEntropy(after[ngram], count))
+		scores = append(scores, ScoredTerm{ngram, cohesion * entropy})
+	}
+	sort.Sort(byScore(scores))
+
+	return scores
+}
+
+// Entropy of the characters next to a phrase counted count times, each
+// occurrence at the boundary of a chunk counting as a distinct character.
+func boundaryEntropy(neighbors map[rune]int, count int) float64 {
+	h := 0.0
+	seen := 0
+	for _, n := range neighbors {
+		p := float64(n) / float64(count)
+		h -= p * math.Log(p)
+		seen += n
+	}
+	if boundary := count - seen; boundary > 0 {
+		h += float64(boundary) / float64(count) * math.Log(float64(count))
+	}
+	return h
+}
+
+// DiscoverPhrases returns the Chinese phrases of the text scoring at
+// least minScore as PhraseScores does, besides those of
+// Options.Vocabulary and Options.ProtectedPhrases, e.g. to find new
+// words such as slang or product names, leaving the counts untouched.
+func (w *WordFeq) DiscoverPhrases(text string, minScore float64) []ScoredTerm {
+	s := w.shard()
+	s.options.Languages = []string{"chinese"}
+	s.options.RecordNGrams = true
+	s.options.RecordOccurrences = false
+	s.options.Cooccurrence = false
+	s.options.Vocabulary = nil
+	s.options.Metrics = nil
+	if err := s.process(text, 1); err != nil {
+		return []ScoredTerm{}
+	}
+
+	known := make(map[string]bool)
+	for _, phrase := range w.options.Vocabulary {
+		known[phrase] = true
+	}
+	for _, phrase := range w.options.ProtectedPhrases {
+		known[phrase] = true
+	}
+
+	_, maxLength := w.options.termLengths("chinese")
+	phrases := make([]ScoredTerm, 0)
+	for _, phrase := range phraseScores(s.ngrams, w.options.MinimumCount, maxLength) {
+		if phrase.Score < minScore {
+			break
+		}
+		if !known[phrase.Term] {
+			phrases = append(phrases, phrase)
+		}
+	}
+	return phrases
+}
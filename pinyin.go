@@ -0,0 +1,393 @@
+package wordfreq
+
+// Pinyin of the common Han characters, simplified and traditional, by
+// syllable and without tones, the most common reading of each.
+var pinyinSyllables = map[string]string{
+	"a":      "阿啊",
+	"ai":     "爱愛哀挨矮艾碍礙唉埃癌",
+	"an":     "安按案暗岸俺鞍氨",
+	"ang":    "昂",
+	"ao":     "奥奧澳傲熬",
+	"ba":     "八把爸吧巴拔霸罢罷坝壩芭",
+	"bai":    "白百摆擺败敗拜柏佰",
+	"ban":    "半办辦班般板版搬伴扮扳颁頒斑",
+	"bang":   "帮幫棒绑綁榜膀",
+	"bao":    "包报報保宝寶抱饱飽暴爆胞薄雹豹",
+	"bei":    "北被备備背杯悲贝貝辈輩倍碑卑",
+	"ben":    "本奔笨",
+	"beng":   "崩",
+	"bi":     "比笔筆必毕畢闭閉避鼻壁币幣彼碧逼鄙臂弊蔽",
+	"bian":   "边邊变變便编編遍辩辯鞭扁",
+	"biao":   "表标標",
+	"bie":    "别別",
+	"bin":    "宾賓滨濱斌",
+	"bing":   "病兵并並冰饼餅柄丙",
+	"bo":     "博波播伯玻脖驳駁剥剝勃",
+	"bu":     "不部步布补補捕埠怖簿",
+	"ca":     "擦",
+	"cai":    "才菜财財采採彩猜材裁",
+	"can":    "参參餐残殘惨慘灿燦蚕蠶",
+	"cang":   "藏仓倉",
+	"cao":    "草操槽糙",
+	"ce":     "测測策侧側册冊厕廁",
+	"ceng":   "层層曾",
+	"cha":    "查茶差插察叉",
+	"chai":   "拆",
+	"chan":   "产產缠纏蝉蟬禅禪铲鏟",
+	"chang":  "长長场場常唱厂廠尝嘗肠腸畅暢倡",
+	"chao":   "超朝潮吵抄钞鈔巢",
+	"che":    "车車彻徹扯撤",
+	"chen":   "陈陳沉晨趁衬襯尘塵臣辰",
+	"cheng":  "成城程称稱承乘诚誠呈撑撐橙惩懲",
+	"chi":    "吃持迟遲尺齿齒池赤翅斥耻恥驰馳",
+	"chong":  "冲衝充虫蟲崇",
+	"chou":   "抽愁臭丑仇绸綢筹籌",
+	"chu":    "出处處初除础礎楚触觸厨廚储儲锄鋤",
+	"chuan":  "穿传傳船川串喘",
+	"chuang": "窗床创創闯闖",
+	"chui":   "吹垂锤錘",
+	"chun":   "春纯純唇",
+	"ci":     "次此词詞辞辭刺磁雌慈",
+	"cong":   "从從聪聰丛叢匆",
+	"cu":     "粗促醋",
+	"cui":    "催脆翠",
+	"cun":    "村存寸",
+	"cuo":    "错錯措挫",
+	"da":     "大打达達答搭",
+	"dai":    "带帶代待袋戴贷貸怠",
+	"dan":    "但单單担擔蛋淡弹彈丹胆膽诞誕",
+	"dang":   "当當党黨挡擋荡蕩档檔",
+	"dao":    "到道倒刀岛島导導稻盗盜悼",
+	"de":     "的得德",
+	"deng":   "等灯燈登凳邓鄧",
+	"di":     "地第低底弟帝敌敵递遞滴笛抵堤",
+	"dian":   "点點电電店典垫墊殿",
+	"diao":   "调調掉钓釣雕吊",
+	"die":    "跌爹叠疊蝶",
+	"ding":   "定顶頂订訂丁盯",
+	"dong":   "东東动動懂冬洞冻凍栋棟",
+	"dou":    "都斗豆抖陡",
+	"du":     "读讀度独獨毒肚督堵渡赌賭杜",
+	"duan":   "短段断斷端锻鍛",
+	"dui":    "对對队隊堆",
+	"dun":    "顿頓吨噸蹲盾",
+	"duo":    "多朵夺奪躲堕墮",
+	"e":      "饿餓恶惡额額鹅鵝俄",
+	"er":     "而二儿兒耳",
+	"fa":     "发發法罚罰乏伐阀閥",
+	"fan":    "反饭飯犯翻凡范範烦煩帆番繁返泛贩販",
+	"fang":   "方放房访訪防芳仿纺紡坊",
+	"fei":    "非飞飛费費肥废廢肺沸",
+	"fen":    "分份粉纷紛奋奮愤憤粪糞坟墳",
+	"feng":   "风風封丰豐峰锋鋒缝縫蜂奉逢",
+	"fo":     "佛",
+	"fou":    "否",
+	"fu":     "父服夫福复復付富副负負府扶浮符幅辅輔腐妇婦抚撫覆附赴",
+	"gai":    "该該改盖蓋概钙鈣",
+	"gan":    "感干乾敢赶趕甘肝杆竿",
+	"gang":   "刚剛钢鋼港岗崗纲綱缸",
+	"gao":    "高告搞糕稿",
+	"ge":     "个個各哥歌格割革阁閣隔鸽鴿",
+	"gei":    "给給",
+	"gen":    "跟根",
+	"geng":   "更耕庚",
+	"gong":   "工公共功攻宫宮恭供巩鞏",
+	"gou":    "够夠狗构構沟溝钩鉤购購",
+	"gu":     "古故顾顧骨谷孤姑鼓固雇估",
+	"gua":    "挂掛瓜刮寡",
+	"guai":   "怪乖拐",
+	"guan":   "关關官管观觀馆館冠贯貫罐惯慣",
+	"guang":  "光广廣",
+	"gui":    "贵貴规規鬼归歸龟龜轨軌柜櫃跪",
+	"gun":    "滚滾棍",
+	"guo":    "国國过過果锅鍋裹",
+	"ha":     "哈",
+	"hai":    "还還孩海害骇駭",
+	"han":    "汉漢喊寒含韩韓罕旱汗",
+	"hang":   "航",
+	"hao":    "好号號毫豪耗浩",
+	"he":     "和合河喝盒核荷何贺賀",
+	"hei":    "黑",
+	"hen":    "很恨",
+	"heng":   "横橫恒衡",
+	"hong":   "红紅洪宏虹哄",
+	"hou":    "后後候厚猴吼",
+	"hu":     "户戶护護湖呼乎忽胡壶壺虎互糊",
+	"hua":    "话話花化画畫华華滑划劃",
+	"huai":   "坏壞怀懷",
+	"huan":   "欢歡换換环環缓緩患唤喚幻",
+	"huang":  "黄黃皇荒慌谎謊",
+	"hui":    "会會回汇匯灰挥揮恢辉輝毁毀悔惠慧",
+	"hun":    "婚混魂",
+	"huo":    "或活火伙货貨获獲祸禍",
+	"ji":     "几幾机機及记記鸡雞急级級基技计計击擊圾积積激极極即集籍寄继繼纪紀际際季迹跡",
+	"jia":    "家加价價假佳夹夾甲驾駕架嫁",
+	"jian":   "见見间間简簡件建尖坚堅监監减減剪检檢健舰艦渐漸践踐箭",
+	"jiang":  "讲講将將江姜奖獎降酱醬",
+	"jiao":   "叫教交角焦胶膠骄驕脚腳较較轿轎",
+	"jie":    "接节節结結姐界街阶階揭洁潔截届屆借戒",
+	"jin":    "进進今近金斤紧緊仅僅尽盡劲勁禁",
+	"jing":   "经經京精静靜惊驚晶景警竞競境敬镜鏡井颈頸",
+	"jiu":    "就九旧舊酒究纠糾救",
+	"ju":     "局句举舉居巨拒具剧劇据據距聚",
+	"juan":   "卷捐",
+	"jue":    "觉覺决決绝絕",
+	"jun":    "军軍均君菌",
+	"ka":     "卡",
+	"kai":    "开開凯凱慨",
+	"kan":    "看刊砍",
+	"kang":   "康抗炕",
+	"kao":    "考烤靠",
+	"ke":     "可科客课課棵颗顆壳殼咳渴克刻",
+	"ken":    "肯垦墾恳懇",
+	"keng":   "坑",
+	"kong":   "空孔控恐",
+	"kou":    "口扣",
+	"ku":     "苦哭库庫裤褲酷",
+	"kua":    "夸誇跨",
+	"kuai":   "快块塊筷",
+	"kuan":   "宽寬",
+	"kuang":  "狂框矿礦况況",
+	"kui":    "亏虧",
+	"kun":    "困昆",
+	"kuo":    "扩擴括阔闊",
+	"la":     "拉垃啦辣",
+	"lai":    "来來赖賴",
+	"lan":    "蓝藍兰蘭拦攔栏欄懒懶烂爛",
+	"lang":   "浪郎狼朗",
+	"lao":    "老劳勞牢",
+	"le":     "了乐樂",
+	"lei":    "类類雷泪淚累",
+	"leng":   "冷",
+	"li":     "里裡理力立利梨离離礼禮李历歷厉厲励勵粒",
+	"lian":   "连連脸臉联聯怜憐练練恋戀",
+	"liang":  "两兩量亮凉涼粮糧良辆輛",
+	"liao":   "料聊疗療辽遼",
+	"lie":    "列烈裂猎獵",
+	"lin":    "林临臨邻鄰",
+	"ling":   "领領铃鈴零龄齡灵靈岭嶺令另",
+	"liu":    "六流刘劉留溜柳",
+	"long":   "龙龍笼籠聋聾隆",
+	"lou":    "楼樓漏",
+	"lu":     "路炉爐卢盧陆陸录錄鹿露",
+	"luan":   "乱亂",
+	"lun":    "论論轮輪",
+	"luo":    "落罗羅萝蘿逻邏骆駱",
+	"lü":     "绿綠旅律率虑慮滤濾铝鋁",
+	"ma":     "妈媽吗嗎马馬麻码碼骂罵",
+	"mai":    "买買卖賣埋麦麥脉脈",
+	"man":    "慢满滿馒饅瞒瞞",
+	"mang":   "忙盲",
+	"mao":    "毛猫貓矛茂冒贸貿帽貌",
+	"me":     "么麼",
+	"mei":    "没沒美每妹媒煤眉梅",
+	"men":    "们們门門闷悶",
+	"meng":   "梦夢蒙盟猛",
+	"mi":     "米迷谜謎秘密蜜",
+	"mian":   "面棉免勉",
+	"miao":   "秒苗描庙廟妙",
+	"mie":    "灭滅",
+	"min":    "民敏",
+	"ming":   "名明命鸣鳴",
+	"mo":     "默摸模膜磨魔抹末莫墨",
+	"mou":    "某",
+	"mu":     "目母木亩畝墓幕慕牧",
+	"na":     "那拿哪纳納",
+	"nai":    "奶耐",
+	"nan":    "男南难難",
+	"nao":    "脑腦闹鬧恼惱",
+	"ne":     "呢",
+	"nei":    "内內",
+	"neng":   "能",
+	"ni":     "你泥尼拟擬逆",
+	"nian":   "年念粘",
+	"niang":  "娘",
+	"niao":   "鸟鳥",
+	"nin":    "您",
+	"ning":   "宁寧凝",
+	"niu":    "牛扭纽紐",
+	"nong":   "农農浓濃弄",
+	"nu":     "努怒奴",
+	"nuan":   "暖",
+	"nuo":    "挪",
+	"nü":     "女",
+	"ou":     "欧歐偶",
+	"pa":     "怕爬",
+	"pai":    "排派牌拍",
+	"pan":    "盘盤判盼攀",
+	"pang":   "旁胖",
+	"pao":    "跑炮泡",
+	"pei":    "陪培赔賠配",
+	"pen":    "盆喷噴",
+	"peng":   "朋碰捧蓬",
+	"pi":     "皮批披疲脾匹屁",
+	"pian":   "片篇偏骗騙",
+	"piao":   "票飘飄漂",
+	"pin":    "品贫貧拼",
+	"ping":   "平评評凭憑瓶苹蘋",
+	"po":     "破坡泼潑婆迫",
+	"pu":     "扑撲铺鋪葡朴普谱譜",
+	"qi":     "起气氣期七其妻欺漆齐齊奇骑騎棋旗企启啟器弃棄汽",
+	"qia":    "恰",
+	"qian":   "前钱錢千牵牽铅鉛谦謙签簽潜潛浅淺欠歉",
+	"qiang":  "强強枪槍墙牆抢搶",
+	"qiao":   "桥橋敲悄巧",
+	"qie":    "且切",
+	"qin":    "亲親侵琴勤秦",
+	"qing":   "请請情清青轻輕倾傾晴庆慶",
+	"qiong":  "穷窮",
+	"qiu":    "球秋求",
+	"qu":     "去取区區曲驱驅趣",
+	"quan":   "全权權泉劝勸拳",
+	"que":    "却卻缺确確",
+	"qun":    "群",
+	"ran":    "然燃染",
+	"rang":   "让讓",
+	"rao":    "绕繞扰擾",
+	"re":     "热熱惹",
+	"ren":    "人认認仁忍任",
+	"reng":   "扔仍",
+	"ri":     "日",
+	"rong":   "容荣榮融",
+	"rou":    "肉",
+	"ru":     "如入乳",
+	"ruan":   "软軟",
+	"rui":    "锐銳",
+	"run":    "润潤",
+	"ruo":    "若弱",
+	"sa":     "撒洒灑",
+	"sai":    "塞赛賽",
+	"san":    "三伞傘散",
+	"sang":   "桑嗓",
+	"sao":    "扫掃嫂",
+	"se":     "色",
+	"sen":    "森",
+	"sha":    "杀殺沙纱紗傻",
+	"shai":   "晒曬",
+	"shan":   "山删刪衫闪閃善扇",
+	"shang":  "上商伤傷赏賞尚",
+	"shao":   "少烧燒稍勺哨",
+	"she":    "社舌蛇舍设設射涉",
+	"shei":   "谁誰",
+	"shen":   "身什神申伸深审審甚渗滲慎沈",
+	"sheng":  "生声聲升牲绳繩省胜勝剩",
+	"shi":    "是时時十事市师師失诗詩施湿濕石识識实實食史使始示士世式势勢视視试試室适適释釋",
+	"shou":   "手收守首寿壽受售授瘦",
+	"shu":    "书書叔梳舒输輸熟属屬鼠数數术術树樹束述",
+	"shua":   "刷",
+	"shuai":  "摔帅帥",
+	"shuang": "双雙霜爽",
+	"shui":   "水睡税稅",
+	"shun":   "顺順",
+	"shuo":   "说說",
+	"si":     "四死司丝絲私思斯寺似",
+	"song":   "送松宋",
+	"sou":    "搜",
+	"su":     "速苏蘇俗诉訴素宿塑",
+	"suan":   "算酸蒜",
+	"sui":    "岁歲虽雖随隨碎",
+	"sun":    "孙孫损損",
+	"suo":    "所缩縮锁鎖索",
+	"ta":     "他她它塔踏",
+	"tai":    "太台臺抬态態",
+	"tan":    "谈談贪貪摊攤滩灘坦叹嘆探碳",
+	"tang":   "糖汤湯唐堂躺趟",
+	"tao":    "讨討逃桃陶套",
+	"te":     "特",
+	"teng":   "疼腾騰",
+	"ti":     "题題体體梯踢提替",
+	"tian":   "天添田甜",
+	"tiao":   "条條挑跳",
+	"tie":    "铁鐵",
+	"ting":   "听聽厅廳停庭挺",
+	"tong":   "同通铜銅童统統桶痛",
+	"tou":    "头頭偷投透",
+	"tu":     "图圖突涂塗途土吐兔",
+	"tuan":   "团團",
+	"tui":    "推腿退",
+	"tun":    "吞",
+	"tuo":    "托脱脫拖妥",
+	"wa":     "挖娃瓦袜襪",
+	"wai":    "外",
+	"wan":    "晚完万萬湾灣玩碗弯彎",
+	"wang":   "王网網往望忘旺亡",
+	"wei":    "为為位危威微围圍违違维維伟偉尾委卫衛未味胃谓謂慰",
+	"wen":    "问問文温溫闻聞稳穩",
+	"wo":     "我握卧臥",
+	"wu":     "五无無乌烏污屋吴吳午武舞物务務误誤悟雾霧",
+	"xi":     "西习習吸希析息悉惜稀溪熄锡錫席洗喜系戏戲细細",
+	"xia":    "下夏虾蝦峡峽狭狹吓嚇",
+	"xian":   "先现現鲜鮮闲閒咸显顯险險县縣线線限宪憲献獻",
+	"xiang":  "想向乡鄉相香箱详詳享响響项項像象",
+	"xiao":   "小笑消销銷晓曉效校",
+	"xie":    "写寫谢謝些协協斜鞋",
+	"xin":    "心新辛欣信",
+	"xing":   "行姓兴興星形型醒幸",
+	"xiong":  "兄凶胸雄熊",
+	"xiu":    "休修秀袖",
+	"xu":     "需须須虚虛许許续續序",
+	"xuan":   "宣选選旋",
+	"xue":    "学學雪血",
+	"xun":    "寻尋训訓迅",
+	"ya":     "呀压壓鸭鴨牙芽亚亞",
+	"yan":    "言眼烟煙延严嚴研颜顏盐鹽演验驗燕",
+	"yang":   "样樣央羊阳陽洋养養",
+	"yao":    "要腰摇搖药藥",
+	"ye":     "也爷爺野业業叶葉页頁夜",
+	"yi":     "一衣医醫依仪儀移遗遺疑已以乙亿億义義艺藝忆憶议議易意",
+	"yin":    "因音阴陰银銀引饮飲印",
+	"ying":   "应應英樱櫻鹰鷹迎营營影硬",
+	"yong":   "用拥擁永勇",
+	"you":    "有优優忧憂由油游友又右",
+	"yu":     "语語于於鱼魚余雨与與宇玉育欲预預域遇",
+	"yuan":   "元原员員园園圆圓源远遠院愿願",
+	"yue":    "月约約越阅閱",
+	"yun":    "运運云雲允",
+	"za":     "杂雜",
+	"zai":    "在灾災再载載",
+	"zan":    "咱赞讚暂暫",
+	"zang":   "脏髒",
+	"zao":    "早遭造",
+	"ze":     "则則责責择擇",
+	"zen":    "怎",
+	"zeng":   "增赠贈",
+	"zha":    "扎炸",
+	"zhai":   "摘窄",
+	"zhan":   "站展占战戰",
+	"zhang":  "张張章涨漲掌丈",
+	"zhao":   "找招照罩",
+	"zhe":    "这這者",
+	"zhen":   "真针針珍阵陣振镇鎮",
+	"zheng":  "正争爭征蒸整证證政",
+	"zhi":    "只知之支汁枝织織职職直植值指止纸紙至志制治质質致置智",
+	"zhong":  "中终終钟鐘种種重众眾",
+	"zhou":   "周州洲舟粥",
+	"zhu":    "主住朱株珠诸諸猪豬竹逐烛燭助注驻駐柱祝著筑築",
+	"zhua":   "抓",
+	"zhuan":  "专專砖磚转轉赚賺",
+	"zhuang": "装裝庄莊壮壯状狀撞",
+	"zhui":   "追",
+	"zhun":   "准準",
+	"zhuo":   "桌捉",
+	"zi":     "子字自资資姿紫",
+	"zong":   "总總宗综綜",
+	"zou":    "走奏",
+	"zu":     "组組租足族祖阻",
+	"zuan":   "钻鑽",
+	"zui":    "最嘴罪醉",
+	"zun":    "尊遵",
+	"zuo":    "做作坐左昨",
+}
+
+// The syllables of pinyinSyllables by character, for Options.Romanize.
+var pinyin = pinyinReadings(pinyinSyllables)
+
+func pinyinReadings(syllables map[string]string) map[rune]string {
+	readings := make(map[rune]string)
+	for syllable, characters := range syllables {
+		for _, r := range characters {
+			readings[r] = syllable
+		}
+	}
+	return readings
+}
@@ -0,0 +1,132 @@
+package wordfreq
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Size of the blocks ProcessReader processes at once.
+const readerBlockSize = 1 << 20
+
+// ProcessReader processes everything read from r, one block at a time,
+// each block ending at a line break when possible so no term is cut in
+// half. Gzip and zip compressed inputs are decompressed, the entries of
+// a zip archive being processed one after the other, then transcoded
+// from Options.Charset.
+func (w *WordFeq) ProcessReader(r io.Reader) ([]Term, error) {
+	total := readerSize(r)
+	r, compressed, err := decompress(r)
+	if err != nil {
+		return w.List(), err
+	}
+	if compressed {
+		total = -1
+	}
+	r, err = transcode(r, w.options.Charset)
+	if err != nil {
+		return w.List(), err
+	}
+
+	err = w.processReader(r, total)
+	return w.List(), err
+}
+
+func (w *WordFeq) processReader(r io.Reader, total int64) error {
+	var done int64
+
+	// all the blocks make a single document
+	if !w.inDocument {
+		w.inDocument = true
+		defer w.endDocument()
+	}
+
+	br := bufio.NewReaderSize(r, readerBlockSize)
+	block := make([]byte, 0, 2*readerBlockSize)
+	var carry []byte
+	for {
+		// read up to the block size, then up to the next line break
+		block = append(block[:0], carry...)
+		carry = carry[:0]
+		var err error
+		for len(block) < readerBlockSize && err == nil {
+			var line []byte
+			line, err = br.ReadSlice('\n')
+			block = append(block, line...)
+		}
+		if err == bufio.ErrBufferFull {
+			err = nil
+		}
+
+		// a very long line: cut it after the last character that is not
+		// part of a word, or at least between two runes, and keep the
+		// rest for the next block
+		if err == nil && len(block) > 0 && block[len(block)-1] != '\n' {
+			cut := len(block) - 1
+			for cut > 0 && !utf8.RuneStart(block[cut]) {
+				cut--
+			}
+			if i := bytes.LastIndexFunc(block[:cut], func(r rune) bool {
+				return r != utf8.RuneError && !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+			}); i >= 0 {
+				_, size := utf8.DecodeRune(block[i:])
+				cut = i + size
+			}
+			if cut > 0 && cut < len(block) {
+				carry = append(carry, block[cut:]...)
+				block = block[:cut]
+			}
+		}
+
+		// a word broken by the last line break, with Options.Dehyphenate:
+		// keep the last line for the next block
+		if err == nil && w.options.Dehyphenate && bytes.HasSuffix(bytes.TrimRight(block, " \t\r\n"), []byte("-")) {
+			if i := bytes.LastIndexByte(block[:len(block)-1], '\n'); i >= 0 {
+				carry = append(carry, block[i+1:]...)
+				block = block[:i+1]
+			}
+		}
+
+		if len(block) > 0 {
+			if perr := w.process(string(block), 1); perr != nil {
+				return perr
+			}
+			done += int64(len(block))
+			if w.options.Progress != nil {
+				w.options.Progress(done, total)
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// The number of bytes left to read, or -1 if unknown.
+func readerSize(r io.Reader) int64 {
+	switch r := r.(type) {
+	case interface{ Len() int }:
+		// bytes.Buffer, bytes.Reader, strings.Reader
+		return int64(r.Len())
+	case *os.File:
+		info, err := r.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return -1
+		}
+		offset, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+		return info.Size() - offset
+	}
+	return -1
+}
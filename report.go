@@ -0,0 +1,113 @@
+package wordfreq
+
+import (
+	"html/template"
+	"io"
+	"math"
+	"sort"
+)
+
+// Options of WriteHTMLReport.
+type ReportOptions struct {
+	Title string // Default: 'Word frequencies'
+	Top   int    // number of terms reported, Default: 50
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em auto; max-width: 60em; color: #222; }
+table { border-collapse: collapse; width: 100%; }
+td, th { padding: 0.2em 0.5em; text-align: left; }
+td.count { text-align: right; }
+td.bar { width: 50%; }
+td.bar div { background: #4a90d9; height: 1em; }
+.cloud { text-align: center; line-height: 1.6; margin: 2em 0; }
+.cloud span { display: inline-block; margin: 0 0.3em; color: #2a5d8f; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>{{.Terms}} terms, {{.Tokens}} tokens.</p>
+<div class="cloud">
+{{- range .Cloud}}
+<span style="font-size: {{.Size}}px">{{.Term}}</span>
+{{- end}}
+</div>
+<table>
+<tr><th>Rank</th><th>Term</th><th>Count</th><th></th></tr>
+{{- range .Rows}}
+<tr><td>{{.Rank}}</td><td>{{.Term}}</td><td class="count">{{.Count}}</td><td class="bar"><div style="width: {{.Width}}%"></div></td></tr>
+{{- end}}
+</table>
+</body>
+</html>
+`))
+
+type reportRow struct {
+	Term  string
+	Count int
+	Rank  int
+	Width float64 // of the bar, in percents of the most frequent term
+	Size  int     // of the term in the cloud, in pixels
+}
+
+// WriteHTMLReport writes a self-contained HTML page reporting the top
+// listed terms, as a word cloud and as a table with frequency bars.
+func (w *WordFeq) WriteHTMLReport(out io.Writer, opts ReportOptions) error {
+	if opts.Title == "" {
+		opts.Title = "Word frequencies"
+	}
+	if opts.Top <= 0 {
+		opts.Top = 50
+	}
+
+	// the most frequent, whatever Options.SortBy
+	list := w.SortedList(SortByCountDesc)
+	if len(list) > opts.Top {
+		list = list[:opts.Top]
+	}
+
+	rows := make([]reportRow, 0, len(list))
+	for _, t := range list {
+		rows = append(rows, reportRow{Term: t.Term, Count: t.Count, Rank: t.Rank})
+	}
+
+	// font sizes from 12 to 48 pixels, by the logarithm of the counts
+	if len(rows) > 0 {
+		min, max := rows[0].Count, rows[0].Count
+		for _, row := range rows {
+			if row.Count < min {
+				min = row.Count
+			}
+			if row.Count > max {
+				max = row.Count
+			}
+		}
+		for i := range rows {
+			rows[i].Width = 100 * float64(rows[i].Count) / float64(max)
+			rows[i].Size = 12
+			if max > min {
+				scale := math.Log(float64(rows[i].Count)/float64(min)) / math.Log(float64(max)/float64(min))
+				rows[i].Size += int(math.Round(36 * scale))
+			}
+		}
+	}
+
+	// the cloud in alphabetical order, as tag clouds usually are
+	cloud := append([]reportRow(nil), rows...)
+	sort.Slice(cloud, func(i, j int) bool {
+		return cloud[i].Term < cloud[j].Term
+	})
+
+	return reportTemplate.Execute(out, struct {
+		Title  string
+		Terms  int
+		Tokens int
+		Rows   []reportRow
+		Cloud  []reportRow
+	}{opts.Title, len(w.terms), w.TotalTokens(), rows, cloud})
+}
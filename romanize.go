@@ -0,0 +1,85 @@
+package wordfreq
+
+import "strings"
+
+// Hepburn romaji of the hiragana, the katakana being mapped to them.
+var romaji = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko",
+	'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so",
+	'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to",
+	'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho",
+	'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o", 'ん': "n",
+	'ゔ': "vu",
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o",
+	'ゃ': "ya", 'ゅ': "yu", 'ょ': "yo", 'ゎ': "wa",
+}
+
+// Romanize the term: the kana to romaji, and the Han characters to
+// their Options.Pinyin readings or the built-in ones, the other
+// characters left as they are.
+func (w *WordFeq) romanize(term string) string {
+	var b strings.Builder
+	double := false // after a small tsu, the next consonant is doubled
+	for _, r := range term {
+		// katakana to hiragana
+		if r >= 'ァ' && r <= 'ヶ' {
+			r -= 'ァ' - 'ぁ'
+		}
+
+		var s string
+		switch r {
+		case 'っ':
+			double = true
+			continue
+		case 'ー':
+			// a long vowel repeats the one before
+			if last := b.String(); last != "" && strings.IndexByte("aeiou", last[len(last)-1]) >= 0 {
+				s = last[len(last)-1:]
+			}
+		case 'ゃ', 'ゅ', 'ょ':
+			// kya, sha, cha: the small y replaces the i before
+			last := b.String()
+			if strings.HasSuffix(last, "i") {
+				b.Reset()
+				last = strings.TrimSuffix(last, "i")
+				if strings.HasSuffix(last, "sh") || strings.HasSuffix(last, "ch") || strings.HasSuffix(last, "j") {
+					b.WriteString(last + romaji[r][1:])
+				} else {
+					b.WriteString(last + romaji[r])
+				}
+				continue
+			}
+			s = romaji[r]
+		default:
+			var ok bool
+			if s, ok = romaji[r]; !ok {
+				if s, ok = w.options.Pinyin[r]; !ok {
+					if s, ok = pinyin[r]; !ok {
+						s = string(r)
+					}
+				}
+			}
+		}
+
+		if double && s != "" && s[0] >= 'b' && s[0] <= 'z' && strings.IndexByte("aeiou", s[0]) < 0 {
+			if strings.HasPrefix(s, "ch") {
+				b.WriteByte('t')
+			} else {
+				b.WriteByte(s[0])
+			}
+		}
+		double = false
+		b.WriteString(s)
+	}
+	return b.String()
+}
@@ -0,0 +1,40 @@
+package wordfreq
+
+import "time"
+
+// When a term was first and last found: the indexes of the processed
+// texts, numbered as in Span.Document, and their times, given to
+// ProcessAt or the processing time otherwise.
+type Seen struct {
+	First   int
+	Last    int
+	FirstAt time.Time
+	LastAt  time.Time
+}
+
+// Record that the term was found in the document at the time.
+func (w *WordFeq) see(term string, document int, at time.Time) {
+	s, ok := w.seen[term]
+	if !ok {
+		w.seen[term] = Seen{document, document, at, at}
+		return
+	}
+	w.seen[term] = s.union(Seen{document, document, at, at})
+}
+
+// The first and last of both.
+func (s Seen) union(o Seen) Seen {
+	if o.First < s.First {
+		s.First = o.First
+	}
+	if o.Last > s.Last {
+		s.Last = o.Last
+	}
+	if o.FirstAt.Before(s.FirstAt) {
+		s.FirstAt = o.FirstAt
+	}
+	if o.LastAt.After(s.LastAt) {
+		s.LastAt = o.LastAt
+	}
+	return s
+}
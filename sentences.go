@@ -0,0 +1,151 @@
+package wordfreq
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Common English abbreviations that end with a full stop without
+// ending the sentence, in lower case and without their full stops.
+var abbreviations = map[string]bool{
+	"mr": true, "mrs": true, "ms": true, "dr": true, "prof": true,
+	"sr": true, "jr": true, "st": true, "mt": true, "vs": true,
+	"etc": true, "eg": true, "ie": true, "cf": true, "al": true,
+	"inc": true, "ltd": true, "co": true, "corp": true, "no": true,
+	"fig": true, "vol": true, "approx": true, "dept": true, "est": true,
+	"jan": true, "feb": true, "mar": true, "apr": true, "jun": true,
+	"jul": true, "aug": true, "sep": true, "sept": true, "oct": true,
+	"nov": true, "dec": true,
+}
+
+func isSentenceTerminator(r rune) bool {
+	switch r {
+	case '.', '!', '?', '。', '！', '？', '…':
+		return true
+	}
+	return false
+}
+
+// Closing quotes and brackets following a terminator still belong
+// to the sentence.
+func isSentenceCloser(r rune) bool {
+	switch r {
+	case '"', '\'', ')', ']', '’', '”', '」', '』', '）', '】', '》':
+		return true
+	}
+	return false
+}
+
+// SplitSentences splits the text into sentences, on Chinese and English
+// sentence terminators and blank lines, leaving full stops of
+// abbreviations, initials and numbers inside the sentence.
+func SplitSentences(text string) []string {
+	spans := sentenceSpans(text)
+	sentences := make([]string, 0, len(spans))
+	for _, span := range spans {
+		sentences = append(sentences, text[span[0]:span[1]])
+	}
+	return sentences
+}
+
+// Return the [start, end) byte offsets of the sentences of the text,
+// without their surrounding white spaces.
+func sentenceSpans(text string) [][]int {
+	spans := make([][]int, 0)
+	push := func(start, end int) {
+		s := strings.TrimSpace(text[start:end])
+		if s == "" {
+			return
+		}
+		start += strings.Index(text[start:end], s)
+		spans = append(spans, []int{start, start + len(s)})
+	}
+
+	start := 0
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+
+		if r == '\n' {
+			// a blank line ends the paragraph, and the sentence
+			j := i + size
+			for j < len(text) && (text[j] == ' ' || text[j] == '\t' || text[j] == '\r') {
+				j++
+			}
+			if j < len(text) && text[j] == '\n' {
+				push(start, i)
+				start = j
+			}
+			i = j
+			continue
+		}
+
+		if !isSentenceTerminator(r) {
+			i += size
+			continue
+		}
+
+		// swallow the whole run of terminators and closers: "?!", ".)"
+		onlyStops := r == '.'
+		end := i + size
+		for end < len(text) {
+			r2, size2 := utf8.DecodeRuneInString(text[end:])
+			if isSentenceTerminator(r2) {
+				onlyStops = onlyStops && r2 == '.'
+			} else if !isSentenceCloser(r2) {
+				break
+			}
+			end += size2
+		}
+
+		if r < utf8.RuneSelf && !isSentenceBreak(text, i, end, onlyStops) {
+			i = end
+			continue
+		}
+
+		push(start, end)
+		start = end
+		i = end
+	}
+	push(start, len(text))
+
+	return spans
+}
+
+// Whether the ASCII terminators at text[i:end] end the sentence.
+func isSentenceBreak(text string, i, end int, onlyStops bool) bool {
+	// must be followed by a white space or the end of the text,
+	// "3.14" or "example.com" are not breaks
+	if end < len(text) {
+		next, _ := utf8.DecodeRuneInString(text[end:])
+		if !unicode.IsSpace(next) {
+			return false
+		}
+	}
+
+	if !onlyStops {
+		return true
+	}
+
+	// the word before the full stop: "Mr." or "J."
+	j := i
+	for j > 0 {
+		prev, size := utf8.DecodeLastRuneInString(text[:j])
+		if !unicode.IsLetter(prev) && prev != '.' {
+			break
+		}
+		j -= size
+	}
+	word := strings.ToLower(strings.Replace(text[j:i], ".", "", -1))
+	if abbreviations[word] || utf8.RuneCountInString(word) == 1 {
+		return false
+	}
+
+	// a lower case word following is still the same sentence
+	rest := strings.TrimLeftFunc(text[end:], unicode.IsSpace)
+	if next, _ := utf8.DecodeRuneInString(rest); unicode.IsLower(next) {
+		return false
+	}
+
+	return true
+}
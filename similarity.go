@@ -0,0 +1,56 @@
+package wordfreq
+
+import (
+	"math"
+)
+
+// Similarity returns the cosine similarity of the term counts of a and
+// b, from 0 for no term in common to 1 for the same proportions.
+func Similarity(a, b *WordFeq) float64 {
+	return cosine(a.terms, b.terms, nil)
+}
+
+// DocumentSimilarity returns the cosine similarity of two documents, by
+// their numbers as with DocumentVector, weighting the terms by TF-IDF
+// with Options.DocumentFrequency. Requires Options.DocumentVectors.
+func (w *WordFeq) DocumentSimilarity(i, j int) float64 {
+	if i < 0 || j < 0 || i >= len(w.vectors) || j >= len(w.vectors) {
+		return 0
+	}
+
+	var idf func(string) float64
+	if w.options.DocumentFrequency {
+		idf = func(term string) float64 {
+			return math.Log(float64(w.dfDocuments) / float64(w.docFreq[term]))
+		}
+	}
+	return cosine(w.vectors[i], w.vectors[j], idf)
+}
+
+// Cosine similarity of two vectors of counts, weighted by idf if not nil.
+func cosine(x, y map[string]int, idf func(string) float64) float64 {
+	weight := func(term string, n int) float64 {
+		if idf == nil {
+			return float64(n)
+		}
+		return float64(n) * idf(term)
+	}
+
+	var dot, nx, ny float64
+	for term, n := range x {
+		wx := weight(term, n)
+		nx += wx * wx
+		if m, ok := y[term]; ok {
+			dot += wx * weight(term, m)
+		}
+	}
+	for term, m := range y {
+		wy := weight(term, m)
+		ny += wy * wy
+	}
+
+	if nx == 0 || ny == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(nx*ny)
+}
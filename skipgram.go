@@ -0,0 +1,45 @@
+package wordfreq
+
+import "sort"
+
+// Pair of English terms, First found before Second within
+// Options.SkipGramWindow words, Count times.
+type SkipGram struct {
+	First  string
+	Second string
+	Count  int
+}
+
+// Count the ordered pairs of the words at most Options.SkipGramWindow
+// words apart, the occurrences being those of the English words in
+// order.
+func (w *WordFeq) countSkipGrams(occurrences []occurrence, weight int) {
+	for i, o := range occurrences {
+		for j := i + 1; j < len(occurrences) && j <= i+w.options.SkipGramWindow; j++ {
+			w.skipGrams[[2]string{o.term, occurrences[j].term}] += weight
+		}
+	}
+}
+
+// SkipGrams returns the top n pairs of words found near each other, or
+// all of them if n <= 0. Requires Options.SkipGramWindow.
+func (w *WordFeq) SkipGrams(n int) []SkipGram {
+	list := make([]SkipGram, 0, len(w.skipGrams))
+	for pair, count := range w.skipGrams {
+		list = append(list, SkipGram{w.bareTerm(pair[0]), w.bareTerm(pair[1]), count})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Count != list[j].Count {
+			return list[i].Count > list[j].Count
+		}
+		if list[i].First != list[j].First {
+			return list[i].First < list[j].First
+		}
+		return list[i].Second < list[j].Second
+	})
+
+	if n > 0 && n < len(list) {
+		list = list[:n]
+	}
+	return list
+}
@@ -0,0 +1,114 @@
+package wordfreq
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"golang.org/x/text/collate"
+)
+
+type SortOrder string
+
+const (
+	SortByCountDesc  SortOrder = "count-desc"   // most frequent first, then lexical
+	SortByCountAsc   SortOrder = "count-asc"    // least frequent first, then lexical
+	SortAlphabetical SortOrder = "alphabetical" // lexical
+	SortByTermLength SortOrder = "term-length"  // longest first, then most frequent
+)
+
+func (o SortOrder) valid() error {
+	switch o {
+	case SortByCountDesc, SortByCountAsc, SortAlphabetical, SortByTermLength:
+		return nil
+	}
+	return fmt.Errorf("wordfreq: unknown sort order %q", string(o))
+}
+
+func (w *WordFeq) sortTerms(list []Term, order SortOrder) {
+	switch order {
+	case SortByCountAsc:
+		sort.Sort(byCountAsc(list))
+	case SortAlphabetical:
+		if w.collator != nil {
+			sort.Sort(byCollation{list, w.collator})
+		} else {
+			sort.Sort(byAlphabet(list))
+		}
+	case SortByTermLength:
+		sort.Sort(byLength(list))
+	default:
+		sort.Sort(byTerm(list))
+	}
+}
+
+// SortedList returns a copy of the list in the given order.
+func (w *WordFeq) SortedList(order SortOrder) []Term {
+	list := w.List()
+	w.sortTerms(list, order)
+	return list
+}
+
+type byCountAsc []Term
+
+func (s byCountAsc) Len() int {
+	return len(s)
+}
+func (s byCountAsc) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+func (s byCountAsc) Less(i, j int) bool {
+	t1 := s[i]
+	t2 := s[j]
+	if t1.Count == t2.Count {
+		return t1.Term < t2.Term
+	} else {
+		return t1.Count < t2.Count
+	}
+}
+
+type byAlphabet []Term
+
+func (s byAlphabet) Len() int {
+	return len(s)
+}
+func (s byAlphabet) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+func (s byAlphabet) Less(i, j int) bool {
+	return s[i].Term < s[j].Term
+}
+
+// Alphabetical with the collation rules of a locale instead of bytes.
+type byCollation struct {
+	list     []Term
+	collator *collate.Collator
+}
+
+func (s byCollation) Len() int {
+	return len(s.list)
+}
+func (s byCollation) Swap(i, j int) {
+	s.list[i], s.list[j] = s.list[j], s.list[i]
+}
+func (s byCollation) Less(i, j int) bool {
+	return s.collator.CompareString(s.list[i].Term, s.list[j].Term) < 0
+}
+
+type byLength []Term
+
+func (s byLength) Len() int {
+	return len(s)
+}
+func (s byLength) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+func (s byLength) Less(i, j int) bool {
+	l1 := utf8.RuneCountInString(s[i].Term)
+	l2 := utf8.RuneCountInString(s[j].Term)
+	if l1 == l2 {
+		return byTerm(s).Less(i, j)
+	} else {
+		return l1 > l2
+	}
+}
@@ -0,0 +1,52 @@
+package wordfreq
+
+// British spellings and their American counterparts, merged with
+// Options.MergeSpellings. Their inflections are merged too, the words
+// being matched by their stems.
+var britishSpellings = map[string]string{
+	"aeroplane": "airplane", "aluminium": "aluminum", "analyse": "analyze",
+	"apologise": "apologize", "armour": "armor", "behaviour": "behavior",
+	"catalogue": "catalog", "centre": "center", "cheque": "check",
+	"civilisation": "civilization", "colour": "color", "counsellor": "counselor",
+	"criticise": "criticize", "defence": "defense", "dialogue": "dialog",
+	"emphasise": "emphasize", "endeavour": "endeavor", "enrol": "enroll",
+	"favour": "favor", "favourite": "favorite", "fibre": "fiber",
+	"flavour": "flavor", "fulfil": "fulfill", "grey": "gray",
+	"harbour": "harbor", "honour": "honor", "humour": "humor",
+	"jewellery": "jewelry", "labour": "labor", "licence": "license",
+	"litre": "liter", "manoeuvre": "maneuver", "metre": "meter",
+	"modelling": "modeling", "neighbour": "neighbor", "offence": "offense",
+	"organisation": "organization", "organise": "organize", "paralyse": "paralyze",
+	"practise": "practice", "programme": "program", "realise": "realize",
+	"recognise": "recognize", "rumour": "rumor", "savour": "savor",
+	"sceptical": "skeptical", "specialise": "specialize", "theatre": "theater",
+	"travelled": "traveled", "travelling": "traveling", "tyre": "tire",
+	"utilise": "utilize", "vapour": "vapor", "vigour": "vigor",
+}
+
+// Return the stems of the spelling variants mapped to the stems of
+// their spellings, nil if there are none.
+func newSpellings(ops Options, lower func(string) string) map[string]string {
+	if !ops.MergeSpellings && len(ops.Spellings) == 0 {
+		return nil
+	}
+
+	spellings := make(map[string]string)
+	var stems *stemCache
+	push := func(variant, spelling string) {
+		from := stems.stem(lower(variant))
+		to := stems.stem(lower(spelling))
+		if from != to {
+			spellings[from] = to
+		}
+	}
+	if ops.MergeSpellings {
+		for variant, spelling := range britishSpellings {
+			push(variant, spelling)
+		}
+	}
+	for variant, spelling := range ops.Spellings {
+		push(variant, spelling)
+	}
+	return spellings
+}
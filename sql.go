@@ -0,0 +1,61 @@
+package wordfreq
+
+import "database/sql"
+
+// ExportSQL writes the counts of all terms to the terms(term, count,
+// language) table of the database, created if needed and emptied first,
+// the database taking ? placeholders, e.g. SQLite or MySQL. The terms
+// are of up to 255 characters, as MySQL keys a VARCHAR(255).
+func (w *WordFeq) ExportSQL(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("CREATE TABLE IF NOT EXISTS terms (term VARCHAR(255) PRIMARY KEY, count INTEGER NOT NULL, language VARCHAR(32) NOT NULL)"); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM terms"); err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare("INSERT INTO terms (term, count, language) VALUES (?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for term, termCount := range w.terms {
+		if _, err := stmt.Exec(term, termCount, w.languages[term]); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ImportSQL adds the counts of the terms table written by ExportSQL to
+// the counted terms.
+func (w *WordFeq) ImportSQL(db *sql.DB) error {
+	rows, err := db.Query("SELECT term, count, language FROM terms")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var term, lang string
+		var termCount int
+		if err := rows.Scan(&term, &termCount, &lang); err != nil {
+			return err
+		}
+
+		w.terms[term] += termCount
+		if _, ok := w.languages[term]; !ok && lang != "" {
+			w.languages[term] = lang
+		}
+	}
+	w.stale = true
+
+	return rows.Err()
+}
@@ -0,0 +1,34 @@
+// Package sqlite saves the counts of a WordFeq to SQLite databases, to
+// query them with SQL.
+package sqlite
+
+import (
+	"database/sql"
+
+	"github.com/twsiyuan/wordfreq"
+	_ "modernc.org/sqlite"
+)
+
+// Export writes the counts of all terms to the terms(term, count,
+// language) table of the SQLite database at path, created if needed.
+func Export(w *wordfreq.WordFeq, path string) error {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return w.ExportSQL(db)
+}
+
+// Import adds the counts of the terms table of the SQLite database at
+// path, written by Export, to w.
+func Import(w *wordfreq.WordFeq, path string) error {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return w.ImportSQL(db)
+}
@@ -0,0 +1,217 @@
+package wordfreq
+
+import (
+	"math"
+	"sort"
+	"unicode/utf8"
+)
+
+// TotalTokens returns the sum of the counts of all terms,
+// regardless of MinimumCount.
+func (w *WordFeq) TotalTokens() int {
+	n := 0
+	for _, termCount := range w.terms {
+		n += termCount
+	}
+	return n
+}
+
+// Lexical statistics over all counted terms, regardless of MinimumCount.
+type Stats struct {
+	Tokens         int     // sum of all term counts
+	Terms          int     // number of distinct terms
+	TypeTokenRatio float64 // Terms / Tokens
+	Hapax          int     // number of terms counted exactly once
+	MeanTermLength float64 // in runes, over distinct terms
+}
+
+func (w *WordFeq) Stats() Stats {
+	s := Stats{Terms: len(w.terms)}
+
+	length := 0
+	for term, termCount := range w.terms {
+		s.Tokens += termCount
+		if termCount == 1 {
+			s.Hapax++
+		}
+		length += utf8.RuneCountInString(w.bareTerm(term))
+	}
+
+	if s.Tokens > 0 {
+		s.TypeTokenRatio = float64(s.Terms) / float64(s.Tokens)
+	}
+	if s.Terms > 0 {
+		s.MeanTermLength = float64(length) / float64(s.Terms)
+	}
+	return s
+}
+
+// Numbers of tokens dropped while processing, i.e. not counted at all.
+type FilteredStats struct {
+	StopWords  int // English stop words, and Chinese ones removed from the phrases
+	Length     int // English words too short or too long
+	Numbers    int // English numbers, without Options.KeepNumbers
+	Vocabulary int // English words not in Options.Vocabulary
+}
+
+// FilteredStats tells how many tokens the options dropped, to check them
+// against a sample document.
+func (w *WordFeq) FilteredStats() FilteredStats {
+	return w.filtered
+}
+
+// ZipfFit fits a power law, count ~ rank^-exponent, to the rank-frequency
+// distribution of all terms by least squares on the log-log scale, and
+// returns the exponent with the coefficient of determination of the fit.
+func (w *WordFeq) ZipfFit() (exponent, rSquared float64) {
+	counts := make([]int, 0, len(w.terms))
+	for _, termCount := range w.terms {
+		counts = append(counts, termCount)
+	}
+	if len(counts) < 2 {
+		return 0, 0
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(counts)))
+
+	n := float64(len(counts))
+	var sx, sy, sxx, sxy, syy float64
+	for i, termCount := range counts {
+		x := math.Log(float64(i + 1))
+		y := math.Log(float64(termCount))
+		sx += x
+		sy += y
+		sxx += x * x
+		sxy += x * y
+		syy += y * y
+	}
+
+	vx := n*sxx - sx*sx
+	vy := n*syy - sy*sy
+	cxy := n*sxy - sx*sy
+
+	exponent = -cxy / vx
+	if vy == 0 {
+		// all terms equally frequent, a flat line fits perfectly
+		return exponent, 1
+	}
+	return exponent, cxy * cxy / (vx * vy)
+}
+
+// Bucket of a frequency histogram: the number of terms counted between
+// Min and Max times, inclusive.
+type Bucket struct {
+	Min   int
+	Max   int
+	Terms int
+}
+
+// Histogram splits the range of term counts into equally wide buckets
+// and counts the terms falling in each of them.
+func (w *WordFeq) Histogram(buckets int) []Bucket {
+	return w.histogram(buckets, false)
+}
+
+// LogHistogram is like Histogram, with bucket widths growing
+// exponentially, which suits the long tail of term counts better.
+func (w *WordFeq) LogHistogram(buckets int) []Bucket {
+	return w.histogram(buckets, true)
+}
+
+func (w *WordFeq) histogram(buckets int, logScale bool) []Bucket {
+	if buckets <= 0 || len(w.terms) == 0 {
+		return []Bucket{}
+	}
+
+	lo, hi := math.MaxInt32, 0
+	for _, termCount := range w.terms {
+		if termCount < lo {
+			lo = termCount
+		}
+		if termCount > hi {
+			hi = termCount
+		}
+	}
+
+	// the upper bounds of the buckets, dropping the empty ranges
+	// when there are more buckets than counts
+	list := make([]Bucket, 0, buckets)
+	min := lo
+	for i := 1; i <= buckets && min <= hi; i++ {
+		var max int
+		if logScale {
+			max = int(math.Ceil(float64(lo) * math.Pow(float64(hi)/float64(lo), float64(i)/float64(buckets))))
+		} else {
+			max = lo + int(math.Ceil(float64(hi-lo+1)*float64(i)/float64(buckets))) - 1
+		}
+		if i == buckets || max > hi {
+			max = hi
+		}
+		if max < min {
+			continue
+		}
+		list = append(list, Bucket{min, max, 0})
+		min = max + 1
+	}
+
+	for _, termCount := range w.terms {
+		i := sort.Search(len(list), func(i int) bool {
+			return list[i].Max >= termCount
+		})
+		list[i].Terms++
+	}
+	return list
+}
+
+// Buckets splits the sorted list into n tiers of equally wide ranges of
+// counts, the least frequent terms first, e.g. to map them to font sizes
+// or colors. Some tiers may be empty, all of them when nothing is listed.
+func (w *WordFeq) Buckets(n int) [][]Term {
+	return w.buckets(n, false)
+}
+
+// LogBuckets is like Buckets, with ranges growing exponentially, which
+// suits the long tail of term counts better.
+func (w *WordFeq) LogBuckets(n int) [][]Term {
+	return w.buckets(n, true)
+}
+
+func (w *WordFeq) buckets(n int, logScale bool) [][]Term {
+	if n <= 0 {
+		return [][]Term{}
+	}
+	tiers := make([][]Term, n)
+
+	list := w.List()
+	if len(list) == 0 {
+		return tiers
+	}
+
+	lo, hi := list[0].Count, list[0].Count
+	for _, t := range list {
+		if t.Count < lo {
+			lo = t.Count
+		}
+		if t.Count > hi {
+			hi = t.Count
+		}
+	}
+
+	for _, t := range list {
+		// all counts equal, the top tier
+		i := n - 1
+		if hi > lo {
+			var f float64
+			if logScale {
+				f = math.Log(float64(t.Count)/float64(lo)) / math.Log(float64(hi)/float64(lo))
+			} else {
+				f = float64(t.Count-lo) / float64(hi-lo)
+			}
+			i = int(f * float64(n))
+			if i >= n {
+				i = n - 1
+			}
+		}
+		tiers[i] = append(tiers[i], t)
+	}
+	return tiers
+}
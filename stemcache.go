@@ -0,0 +1,55 @@
+package wordfreq
+
+import (
+	"container/list"
+	"strings"
+
+	"github.com/reiver/go-porterstemmer"
+)
+
+// Least recently used cache of the stems of words, bounded in size.
+type stemCache struct {
+	size    int
+	entries map[string]*list.Element
+	order   *list.List // of *stemEntry, most recently used first
+}
+
+type stemEntry struct {
+	word string
+	stem string
+}
+
+// Return a cache of size stems, or nil, i.e. no cache, if size <= 0.
+func newStemCache(size int) *stemCache {
+	if size <= 0 {
+		return nil
+	}
+	return &stemCache{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func (c *stemCache) stem(word string) string {
+	if c == nil {
+		return porterstemmer.StemString(word)
+	}
+
+	if e, ok := c.entries[word]; ok {
+		c.order.MoveToFront(e)
+		return e.Value.(*stemEntry).stem
+	}
+
+	if c.order.Len() >= c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*stemEntry).word)
+	}
+
+	// the word may share the memory of the whole text
+	word = strings.Clone(word)
+	stem := porterstemmer.StemString(word)
+	c.entries[word] = c.order.PushFront(&stemEntry{word, stem})
+	return stem
+}
@@ -0,0 +1,183 @@
+package wordfreq
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// AddTerm adds count to the term, e.g. to merge counts computed
+// elsewhere into the same list.
+func (w *WordFeq) AddTerm(term string, count int) {
+	if count <= 0 {
+		return
+	}
+
+	// a term given as LanguageTerm(lang, term)
+	if i := strings.Index(term, ":"); w.options.SeparateLanguages && i >= 0 {
+		if _, ok := w.languages[term]; !ok {
+			w.languages[term] = term[:i]
+		}
+	}
+
+	w.terms[term] += count
+	if w.options.HalfLife > 0 {
+		w.decay.add(term, count, time.Now(), w.options.HalfLife)
+	}
+	w.stale = true
+}
+
+// DeleteTerm forgets everything counted about the term.
+func (w *WordFeq) DeleteTerm(term string) {
+	delete(w.terms, term)
+	delete(w.occurrences, term)
+	delete(w.variants, term)
+	delete(w.languages, term)
+	delete(w.docFreq, term)
+	delete(w.absorbed, term)
+	delete(w.decay.weights, term)
+	delete(w.seen, term)
+	for _, vector := range w.vectors {
+		delete(vector, term)
+	}
+	for pair := range w.skipGrams {
+		if pair[0] == term || pair[1] == term {
+			delete(w.skipGrams, pair)
+		}
+	}
+
+	for related := range w.cooccurrences[term] {
+		delete(w.cooccurrences[related], term)
+	}
+	delete(w.cooccurrences, term)
+
+	w.stale = true
+}
+
+// MergeTerms adds the counts of the srcs terms into dst, then deletes
+// them, e.g. to merge duplicate spellings.
+func (w *WordFeq) MergeTerms(dst string, srcs ...string) {
+	for _, src := range srcs {
+		if src == dst {
+			continue
+		}
+
+		if n, ok := w.terms[src]; ok {
+			if _, ok := w.terms[dst]; !ok {
+				w.languages[dst] = w.languages[src]
+			}
+			w.terms[dst] += n
+		}
+
+		if spans, ok := w.occurrences[src]; ok {
+			w.occurrences[dst] = append(w.occurrences[dst], spans...)
+		}
+
+		// documents with both cannot be told apart anymore
+		if w.docFreq[src] > w.docFreq[dst] {
+			w.docFreq[dst] = w.docFreq[src]
+		}
+
+		for word, n := range w.variants[src] {
+			if w.variants[dst] == nil {
+				w.variants[dst] = make(map[string]int)
+			}
+			w.variants[dst][word] += n
+		}
+
+		for _, vector := range w.vectors {
+			if n, ok := vector[src]; ok {
+				vector[dst] += n
+			}
+		}
+
+		if weight, ok := w.decay.weights[src]; ok {
+			w.decay.weights[dst] += weight
+		}
+
+		if seen, ok := w.seen[src]; ok {
+			if old, ok := w.seen[dst]; ok {
+				seen = old.union(seen)
+			}
+			w.seen[dst] = seen
+		}
+
+		for pair, n := range w.skipGrams {
+			if pair[0] != src && pair[1] != src {
+				continue
+			}
+			if pair[0] == src {
+				pair[0] = dst
+			}
+			if pair[1] == src {
+				pair[1] = dst
+			}
+			if pair[0] != pair[1] {
+				w.skipGrams[pair] += n
+			}
+		}
+
+		for substring, n := range w.absorbed[src] {
+			if w.absorbed[dst] == nil {
+				w.absorbed[dst] = make(map[string]int)
+			}
+			w.absorbed[dst][substring] += n
+		}
+
+		for related, n := range w.cooccurrences[src] {
+			if related == dst {
+				continue
+			}
+
+			if w.cooccurrences[dst] == nil {
+				w.cooccurrences[dst] = make(map[string]int)
+			}
+			if w.cooccurrences[related] == nil {
+				w.cooccurrences[related] = make(map[string]int)
+			}
+			w.cooccurrences[dst][related] += n
+			w.cooccurrences[related][dst] += n
+		}
+
+		w.DeleteTerm(src)
+	}
+}
+
+// Variants returns the words merged into an English term by stemming,
+// e.g. book, books and booking, with their own counts.
+func (w *WordFeq) Variants(term string) []Term {
+	forms := w.variants[term]
+	list := make([]Term, 0, len(forms))
+	for word, n := range forms {
+		list = append(list, Term{Term: word, Count: n})
+	}
+	sort.Sort(byTerm(list))
+	return list
+}
+
+// ListByLanguage splits the sorted list by the language the terms were
+// found in, the terms from AddTerm being listed under "".
+func (w *WordFeq) ListByLanguage() map[string][]Term {
+	w.sync()
+
+	lists := make(map[string][]Term)
+	for _, t := range w.list {
+		lists[t.Language] = append(lists[t.Language], t)
+	}
+	return lists
+}
+
+// NGrams returns the raw counts of the Chinese N-grams of n characters,
+// before the substrings are filtered out, e.g. to train a language
+// model. Requires Options.RecordNGrams.
+func (w *WordFeq) NGrams(n int) []Term {
+	list := make([]Term, 0)
+	for ngram, count := range w.ngrams {
+		if utf8.RuneCountInString(ngram) == n {
+			list = append(list, Term{Term: ngram, Count: count, Language: "chinese"})
+		}
+	}
+	sort.Sort(byTerm(list))
+	return list
+}
@@ -0,0 +1,139 @@
+package wordfreq
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// Token found in a text, at the [Start, End) byte offsets.
+type Token struct {
+	Text  string
+	Start int
+	End   int
+}
+
+// Tokenizer splits a text into tokens, without counting nor filtering
+// them.
+type Tokenizer interface {
+	Tokens(text string) []Token
+}
+
+// NewTokenizer returns the tokenizer of the language, "english",
+// "chinese" or "characters", configured by the options.
+func NewTokenizer(lang string, ops Options) (Tokenizer, error) {
+	w, err := New(ops)
+	if err != nil {
+		return nil, err
+	}
+
+	t := w.Tokenizer(lang)
+	if t == nil {
+		return nil, fmt.Errorf("wordfreq: unknown language %q", lang)
+	}
+	return t, nil
+}
+
+// Tokenizer returns the tokenizer the language is processed with, or
+// nil for an unknown language.
+func (w *WordFeq) Tokenizer(lang string) Tokenizer {
+	switch lang {
+	case "english":
+		return englishTokenizer{w}
+	case "chinese":
+		return chineseTokenizer{w}
+	case "characters":
+		return charactersTokenizer{w}
+	}
+	return nil
+}
+
+// The normalized English words, before the stop words and the length
+// filter.
+type englishTokenizer struct {
+	w *WordFeq
+}
+
+func (t englishTokenizer) Tokens(text string) []Token {
+	words := t.w.englishWords(text)
+	tokens := make([]Token, 0, len(words))
+	for _, word := range words {
+		tokens = append(tokens, Token{word.word, word.start, word.end})
+	}
+	return tokens
+}
+
+// All the N-grams of the Chinese chunks, of the phrase lengths.
+type chineseTokenizer struct {
+	w *WordFeq
+}
+
+func (t chineseTokenizer) Tokens(text string) []Token {
+	minLength, maxLength := t.w.options.termLengths("chinese")
+	chunks, _ := chineseChunks(text, t.w.chSplit, t.w.options.ChineseBoundaries, t.w.stopWords["chinese"], t.w.options.StopWordMode)
+
+	tokens := make([]Token, 0)
+	for _, span := range chunks {
+		chunk := text[span[0]:span[1]]
+		for i := range chunk {
+			for j, n := i, 0; j < len(chunk) && n < maxLength; n++ {
+				_, size := utf8.DecodeRuneInString(chunk[j:])
+				j += size
+				if n+1 < minLength {
+					continue
+				}
+				tokens = append(tokens, Token{chunk[i:j], span[0] + i, span[0] + j})
+			}
+		}
+	}
+	return tokens
+}
+
+// Every letter on its own.
+type charactersTokenizer struct {
+	w *WordFeq
+}
+
+func (t charactersTokenizer) Tokens(text string) []Token {
+	return t.w.characterTokens(text)
+}
+
+// Tokenize returns the terms the text is counted as, by all the
+// configured languages, in order of appearance, leaving the counts
+// untouched. The words are represented as in this text alone.
+func (w *WordFeq) Tokenize(text string) []string {
+	s := w.shard()
+	s.options.RecordOccurrences = true
+	s.options.Cooccurrence = false
+	s.options.ExtractKeywords = false
+	s.options.Metrics = nil
+	if err := s.process(text, 1); err != nil {
+		return []string{}
+	}
+
+	type termSpan struct {
+		term       string
+		start, end int
+	}
+	spans := make([]termSpan, 0)
+	for term, occurrences := range s.occurrences {
+		for _, span := range occurrences {
+			spans = append(spans, termSpan{term, span.Start, span.End})
+		}
+	}
+	sort.Slice(spans, func(i, j int) bool {
+		if spans[i].start == spans[j].start {
+			if spans[i].end == spans[j].end {
+				return spans[i].term < spans[j].term
+			}
+			return spans[i].end < spans[j].end
+		}
+		return spans[i].start < spans[j].start
+	})
+
+	tokens := make([]string, 0, len(spans))
+	for _, span := range spans {
+		tokens = append(tokens, s.bareTerm(span.term))
+	}
+	return tokens
+}
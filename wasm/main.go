@@ -0,0 +1,66 @@
+//go:build js && wasm
+
+// Command wasm exposes wordfreq to JavaScript, in place of the original
+// JavaScript wordfreq in browsers:
+//
+//	wordfreq.process(text[, options]) -> [[term, count], ...]
+//
+// the options being those of wordfreq.Options, e.g. {"Languages": ["english"]}.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"syscall/js"
+
+	"github.com/twsiyuan/wordfreq"
+)
+
+func process(this js.Value, args []js.Value) (result interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = js.Global().Get("Error").New(fmt.Sprint(r))
+		}
+	}()
+
+	terms, err := processArgs(args)
+	if err != nil {
+		return js.Global().Get("Error").New(err.Error())
+	}
+
+	list := make([]interface{}, 0, len(terms))
+	for _, t := range terms {
+		list = append(list, []interface{}{t.Term, t.Count})
+	}
+	return list
+}
+
+func processArgs(args []js.Value) ([]wordfreq.Term, error) {
+	if len(args) == 0 || args[0].Type() != js.TypeString {
+		return nil, errors.New("wordfreq: process expects a text")
+	}
+
+	var ops wordfreq.Options
+	if len(args) > 1 && args[1].Type() == js.TypeObject {
+		options := js.Global().Get("JSON").Call("stringify", args[1]).String()
+		if err := json.Unmarshal([]byte(options), &ops); err != nil {
+			return nil, err
+		}
+	}
+
+	w, err := wordfreq.New(ops)
+	if err != nil {
+		return nil, err
+	}
+	return w.ProcessE(args[0].String())
+}
+
+func main() {
+	js.Global().Set("wordfreq", js.ValueOf(map[string]interface{}{
+		"process": js.FuncOf(process),
+	}))
+
+	// keep the functions callable
+	select {}
+}
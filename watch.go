@@ -0,0 +1,89 @@
+package wordfreq
+
+import "sort"
+
+// Number of terms a Watch channel holds, the others being dropped until
+// they are received.
+const watchBuffer = 64
+
+type watcher struct {
+	minCount int
+	ch       chan Term
+	missed   int // terms dropped, the channel being full
+}
+
+// Watch returns a channel receiving every term the first time its count
+// reaches minCount, once the text it is found in is processed, e.g. by
+// Process or ProcessAll. Processing never waits for the channel to be
+// received from: the terms it has no room for are dropped, and counted
+// by Missed.
+func (w *WordFeq) Watch(minCount int) <-chan Term {
+	ch := make(chan Term, watchBuffer)
+
+	w.watchMu.Lock()
+	defer w.watchMu.Unlock()
+	w.watchers = append(w.watchers, &watcher{minCount: minCount, ch: ch})
+	return ch
+}
+
+// Unwatch stops sending to the channel returned by Watch, and closes it.
+// It may be called while processing, e.g. by the receiver.
+func (w *WordFeq) Unwatch(ch <-chan Term) {
+	w.watchMu.Lock()
+	defer w.watchMu.Unlock()
+	for i, watcher := range w.watchers {
+		if watcher.ch == ch {
+			close(watcher.ch)
+			w.watchers = append(w.watchers[:i], w.watchers[i+1:]...)
+			return
+		}
+	}
+}
+
+// Missed returns the number of terms dropped from the channel returned
+// by Watch for lack of room.
+func (w *WordFeq) Missed(ch <-chan Term) int {
+	w.watchMu.Lock()
+	defer w.watchMu.Unlock()
+	for _, watcher := range w.watchers {
+		if watcher.ch == ch {
+			return watcher.missed
+		}
+	}
+	return 0
+}
+
+// Whether any channel is watching.
+func (w *WordFeq) watching() bool {
+	w.watchMu.Lock()
+	defer w.watchMu.Unlock()
+	return len(w.watchers) > 0
+}
+
+// Send the terms whose counts reached the thresholds of the watchers,
+// given their counts before.
+func (w *WordFeq) notify(before map[string]int) {
+	if len(before) == 0 {
+		return
+	}
+
+	terms := make([]string, 0, len(before))
+	for term := range before {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+
+	w.watchMu.Lock()
+	defer w.watchMu.Unlock()
+	for _, watcher := range w.watchers {
+		for _, term := range terms {
+			if before[term] < watcher.minCount && w.terms[term] >= watcher.minCount {
+				select {
+				case watcher.ch <- w.newTerm(term, w.terms[term]):
+				default:
+					watcher.missed++
+				}
+			}
+		}
+	}
+}
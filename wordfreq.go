@@ -1,21 +1,82 @@
 package wordfreq
 
 import (
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
 	"regexp"
 	"sort"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
 	"unicode/utf8"
 
-	"github.com/reiver/go-porterstemmer"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+	"golang.org/x/text/width"
 )
 
 type Options struct {
-	Languages          []string // Default: ['chinese', 'english']
-	StopWordSets       []string // Default: ['cjk', 'english1', 'english2']
-	StopWords          []string // Default: []
-	NoFilterSubstring  bool     // Default: false
-	MaxiumPhraseLength int      // Default: 8
-	MinimumCount       int      // Default: 2
+	Languages           []string                               // Default: ['chinese', 'english']
+	StopWordSets        []string                               // Default: ['cjk', 'english1', 'english2']
+	StopWords           []string                               // Default: []
+	StopWordsByLanguage map[string][]string                    // Default: {}
+	NoFilterSubstring   bool                                   // Default: false
+	FilterAcrossTexts   bool                                   // Default: false
+	SubstringRatio      float64                                // Default: 1, i.e. exact same counts
+	MaxiumPhraseLength  int                                    // Default: 8
+	MaxChunkLength      int                                    // Default: 1000
+	MinimumPhraseLength int                                    // Default: 2
+	MinimumTermLength   int                                    // Default: 0, i.e. 3 for English, MinimumPhraseLength for Chinese
+	MaximumTermLength   int                                    // Default: 0, i.e. unlimited
+	MinimumCount        int                                    // Default: 2
+	ExtractKeywords     bool                                   // Default: false
+	Cooccurrence        bool                                   // Default: false
+	RecordAbsorbed      bool                                   // Default: false
+	RecordNGrams        bool                                   // Default: false
+	RecordSeen          bool                                   // Default: false
+	RecordDelta         bool                                   // Default: false
+	SkipGramWindow      int                                    // Default: 0, i.e. disabled
+	RecordOccurrences   bool                                   // Default: false
+	RelativeFrequency   bool                                   // Default: false
+	SortBy              SortOrder                              // Default: 'count-desc'
+	EnglishSplitPattern string                                 // Default: EnglishSplitPattern
+	ChineseSplitPattern string                                 // Default: '', i.e. derived from IncludeKana and Scripts
+	ChineseBoundaries   string                                 // Default: ChineseBoundaries
+	KeepNumbers         bool                                   // Default: false
+	CaseSensitive       bool                                   // Default: false
+	KeepNames           bool                                   // Default: false
+	KeepAbbreviations   bool                                   // Default: false
+	HyphenMode          string                                 // Default: 'compound'
+	Dehyphenate         bool                                   // Default: false
+	IncludeKana         bool                                   // Default: false
+	Scripts             []string                               // Default: [], i.e. Han only
+	Romanize            bool                                   // Default: false
+	CompoundLexicon     []string                               // Default: []
+	MergeSpellings      bool                                   // Default: false
+	Spellings           map[string]string                      // Default: nil
+	Pinyin              map[rune]string                        // Default: nil
+	FoldWidth           bool                                   // Default: false
+	ExpandContractions  bool                                   // Default: false
+	Abbreviations       []string                               // Default: []
+	ExactStopWords      bool                                   // Default: false
+	SeparateLanguages   bool                                   // Default: false
+	InvalidUTF8         string                                 // Default: 'replace'
+	Progress            func(bytesProcessed, totalBytes int64) // Default: nil
+	Metrics             Metrics                                // Default: nil
+	DocumentFrequency   bool                                   // Default: false
+	DocumentVectors     bool                                   // Default: false
+	Charset             string                                 // Default: 'utf-8'
+	StemCacheSize       int                                    // Default: 10000, negative to disable
+	StopWordMode        string                                 // Default: 'remove'
+	ProtectedPhrases    []string                               // Default: []
+	Vocabulary          []string                               // Default: [], i.e. every term
+	HalfLife            time.Duration                          // Default: 0, i.e. no decay
+	CollationLocale     string                                 // Default: '', i.e. byte order
+	Locale              string                                 // Default: '', i.e. no locale-specific casing
 }
 
 func New(ops Options) (*WordFeq, error) {
@@ -34,73 +95,887 @@ func New(ops Options) (*WordFeq, error) {
 	if ops.MaxiumPhraseLength <= 0 {
 		ops.MaxiumPhraseLength = 8
 	}
+	if ops.SubstringRatio == 0 {
+		ops.SubstringRatio = 1
+	}
+	if ops.MaxChunkLength <= 0 {
+		ops.MaxChunkLength = 1000
+	}
+	if ops.StemCacheSize == 0 {
+		ops.StemCacheSize = 10000
+	}
+
+	if ops.MinimumPhraseLength <= 0 {
+		ops.MinimumPhraseLength = 2
+	}
 
 	if ops.MinimumCount <= 0 {
 		ops.MinimumCount = 2
 	}
 
-	ops.StopWords = append(ops.StopWords, stopWordsFromSets(ops.StopWordSets)...)
+	if ops.SortBy == "" {
+		ops.SortBy = SortByCountDesc
+	}
+
+	if ops.InvalidUTF8 == "" {
+		ops.InvalidUTF8 = "replace"
+	}
+	if ops.Charset == "" {
+		ops.Charset = "utf-8"
+	}
+	if ops.HyphenMode == "" {
+		ops.HyphenMode = "compound"
+	}
+	if ops.StopWordMode == "" {
+		ops.StopWordMode = "remove"
+	}
+
+	if err := ops.validate(); err != nil {
+		return nil, err
+	}
+
+	if ops.EnglishSplitPattern == "" {
+		ops.EnglishSplitPattern = EnglishSplitPattern
+	}
+
+	engSplit, err := regexp.Compile(ops.EnglishSplitPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	// the characters of the Chinese phrases, the other scripts being
+	// matched by their Unicode properties
+	chSplit := chReplace
+	switch {
+	case ops.ChineseSplitPattern != "":
+		chSplit, err = regexp.Compile(ops.ChineseSplitPattern)
+		if err != nil {
+			return nil, err
+		}
+	case ops.IncludeKana || len(ops.Scripts) > 0:
+		class := hanClass
+		if ops.IncludeKana {
+			class += kanaClass
+		}
+		for _, script := range ops.Scripts {
+			class += `\p{` + script + "}"
+		}
+		chSplit = regexp.MustCompile("[^" + class + "]+")
+	}
+
+	if ops.ChineseBoundaries == "" {
+		ops.ChineseBoundaries = ChineseBoundaries
+	}
+
+	collator, err := newCollator(ops.CollationLocale)
+	if err != nil {
+		return nil, err
+	}
+
+	lower, err := newLower(ops.Locale)
+	if err != nil {
+		return nil, err
+	}
+
+	// the Chinese stop words cut the chunks, the English ones filter
+	// words: the built-in sets by their language, the others Chinese when
+	// made of the characters of the phrases, kana with IncludeKana, and
+	// English unless made of Han characters
+	isChinese := func(word string) bool {
+		return word != "" && !chSplit.MatchString(word)
+	}
+	stopWords := make(map[string][]string)
+	for _, stopWord := range ops.StopWords {
+		if isChinese(stopWord) {
+			stopWords["chinese"] = append(stopWords["chinese"], stopWord)
+		}
+		if !chTest.MatchString(stopWord) {
+			stopWords["english"] = append(stopWords["english"], stopWord)
+		}
+	}
+	for _, set := range ops.StopWordSets {
+		lang := "english"
+		if set == "cjk" {
+			lang = "chinese"
+		}
+		stopWords[lang] = append(stopWords[lang], stopWordsFromSets([]string{set})...)
+	}
+	for lang, words := range ops.StopWordsByLanguage {
+		for _, stopWord := range words {
+			if lang != "chinese" || isChinese(stopWord) {
+				stopWords[lang] = append(stopWords[lang], stopWord)
+			}
+		}
+	}
+
+	// the built-in abbreviations, without full stops
+	var abbrevs map[string]bool
+	if ops.KeepAbbreviations {
+		abbrevs = make(map[string]bool)
+		for abbreviation := range abbreviations {
+			abbrevs[abbreviation] = true
+		}
+		for _, abbreviation := range ops.Abbreviations {
+			abbrevs[lower(strings.Replace(abbreviation, ".", "", -1))] = true
+		}
+	}
+
+	var lexicon map[string]bool
+	if len(ops.CompoundLexicon) > 0 {
+		lexicon = make(map[string]bool)
+		for _, word := range ops.CompoundLexicon {
+			lexicon[lower(word)] = true
+		}
+	}
+
+	// English words of the vocabulary are matched by their stems
+	var vocabulary map[string]bool
+	if len(ops.Vocabulary) > 0 {
+		vocabulary = make(map[string]bool)
+		var stems *stemCache
+		for _, word := range ops.Vocabulary {
+			vocabulary[lower(word)] = true
+			vocabulary[stems.stem(lower(word))] = true
+		}
+	}
 
 	return &WordFeq{
-		options: ops,
-		terms:   make(map[string]int),
-		list:    make([]Term, 0),
+		options:       ops,
+		terms:         make(map[string]int),
+		list:          make([]Term, 0),
+		ranks:         make(map[string]int),
+		rake:          newRakeStats(),
+		cooccurrences: make(map[string]map[string]int),
+		occurrences:   make(map[string][]Span),
+		variants:      make(map[string]map[string]int),
+		languages:     make(map[string]string),
+		docFreq:       make(map[string]int),
+		absorbed:      make(map[string]map[string]int),
+		ngrams:        make(map[string]int),
+		skipGrams:     make(map[[2]string]int),
+		seen:          make(map[string]Seen),
+		docTerms:      make(map[string]int),
+		stems:         newStemCache(ops.StemCacheSize),
+		vocabulary:    vocabulary,
+		stopWords:     stopWords,
+		abbreviations: abbrevs,
+		lexicon:       lexicon,
+		spellings:     newSpellings(ops, lower),
+		decay:         newDecayStats(),
+		collator:      collator,
+		lower:         lower,
+		engSplit:      engSplit,
+		chSplit:       chSplit,
 	}, nil
 }
 
+// The options with the non-zero fields of override in place of theirs.
+func (ops Options) overriddenBy(override Options) Options {
+	dst := reflect.ValueOf(&ops).Elem()
+	src := reflect.ValueOf(override)
+	for i := 0; i < src.NumField(); i++ {
+		if field := src.Field(i); !field.IsZero() {
+			dst.Field(i).Set(field)
+		}
+	}
+	return ops
+}
+
+// Check the options for unknown keywords and inconsistent values.
+func (ops *Options) validate() error {
+	for _, lang := range ops.Languages {
+		switch lang {
+		case "english", "chinese", "characters":
+		default:
+			return fmt.Errorf("wordfreq: unknown language %q", lang)
+		}
+	}
+
+	for _, set := range ops.StopWordSets {
+		switch set {
+		case "cjk", "english1", "english2":
+		default:
+			return fmt.Errorf("wordfreq: unknown stop word set %q", set)
+		}
+	}
+
+	switch ops.InvalidUTF8 {
+	case "replace", "skip", "error":
+	default:
+		return fmt.Errorf("wordfreq: unknown invalid UTF-8 policy %q", ops.InvalidUTF8)
+	}
+
+	if _, ok := charsets[ops.Charset]; !ok && ops.Charset != "auto" {
+		return fmt.Errorf("wordfreq: unknown charset %q", ops.Charset)
+	}
+
+	for _, script := range ops.Scripts {
+		if _, ok := unicode.Scripts[script]; !ok {
+			return fmt.Errorf("wordfreq: unknown script %q", script)
+		}
+	}
+
+	for lang := range ops.StopWordsByLanguage {
+		switch lang {
+		case "english", "chinese":
+		default:
+			return fmt.Errorf("wordfreq: unknown language %q of stop words", lang)
+		}
+	}
+
+	if ops.HalfLife < 0 {
+		return fmt.Errorf("wordfreq: negative HalfLife %v", ops.HalfLife)
+	}
+
+	if ops.SubstringRatio < 0 || ops.SubstringRatio > 1 {
+		return fmt.Errorf("wordfreq: SubstringRatio %g is not between 0 and 1", ops.SubstringRatio)
+	}
+
+	switch ops.HyphenMode {
+	case "compound", "split", "both":
+	default:
+		return fmt.Errorf("wordfreq: unknown hyphen mode %q", ops.HyphenMode)
+	}
+
+	switch ops.StopWordMode {
+	case "remove", "split", "keep":
+	default:
+		return fmt.Errorf("wordfreq: unknown stop word mode %q", ops.StopWordMode)
+	}
+
+	if ops.MaximumTermLength > 0 && ops.MinimumTermLength > ops.MaximumTermLength {
+		return fmt.Errorf("wordfreq: MinimumTermLength %d is greater than MaximumTermLength %d", ops.MinimumTermLength, ops.MaximumTermLength)
+	}
+
+	if ops.MinimumPhraseLength > ops.MaxiumPhraseLength {
+		return fmt.Errorf("wordfreq: MinimumPhraseLength %d is greater than MaxiumPhraseLength %d", ops.MinimumPhraseLength, ops.MaxiumPhraseLength)
+	}
+
+	return ops.SortBy.valid()
+}
+
+// Whether a substring counted subCount times is filtered out in favor of
+// a longer term containing it counted termCount times.
+func (ops *Options) absorbs(termCount, subCount int) bool {
+	if ops.SubstringRatio >= 1 {
+		return termCount == subCount
+	}
+	return subCount > 0 && float64(termCount) >= ops.SubstringRatio*float64(subCount)
+}
+
+// Range of term lengths, in runes, counted for a language. maxLength is
+// 0 when unlimited.
+func (ops *Options) termLengths(lang string) (minLength, maxLength int) {
+	minLength = ops.MinimumTermLength
+	maxLength = ops.MaximumTermLength
+
+	switch lang {
+	case "english":
+		if minLength <= 0 {
+			minLength = 3
+		}
+	case "chinese":
+		if minLength < ops.MinimumPhraseLength {
+			minLength = ops.MinimumPhraseLength
+		}
+		if maxLength <= 0 || maxLength > ops.MaxiumPhraseLength {
+			maxLength = ops.MaxiumPhraseLength
+		}
+	}
+	return
+}
+
 type WordFeq struct {
-	options Options
-	terms   map[string]int
-	list    []Term
+	options       Options
+	terms         map[string]int
+	list          []Term
+	ranks         map[string]int // term -> Rank in list
+	rake          *rakeStats
+	cooccurrences map[string]map[string]int
+	occurrences   map[string][]Span
+	variants      map[string]map[string]int // English term -> word -> count
+	languages     map[string]string         // term -> language it was found in
+	docFreq       map[string]int            // term -> number of documents it was found in
+	absorbed      map[string]map[string]int // term -> substring filtered out in favor of it -> count
+	docTerms      map[string]int            // term -> count in the current document
+	vectors       []map[string]int          // docTerms of every document, with Options.DocumentVectors
+	dfDocuments   int                       // number of documents, a file being one document
+	inDocument    bool                      // several texts are processed as one document
+	stems         *stemCache                // nil when disabled
+	vocabulary    map[string]bool           // stems of Options.Vocabulary, nil for every term
+	stopWords     map[string][]string       // language -> stop words
+	abbreviations map[string]bool           // with Options.KeepAbbreviations, without full stops
+	lexicon       map[string]bool           // Options.CompoundLexicon, in lower case
+	spellings     map[string]string         // stem of a spelling variant -> stem of the spelling
+	filtered      FilteredStats             // tokens dropped while processing
+	decay         *decayStats               // with Options.HalfLife
+	at            time.Time                 // time of the text given to ProcessAt
+	texts         []string                  // processed texts, kept along with occurrences
+	ngrams        map[string]int            // Chinese substring -> count before the filter, with Options.RecordNGrams
+	skipGrams     map[[2]string]int         // pair of English terms -> count, with Options.SkipGramWindow
+	watchers      []*watcher                // channels of Watch
+	watchMu       sync.Mutex                // guards watchers, Unwatch being called while processing
+	seen          map[string]Seen           // with Options.RecordSeen
+	delta         map[string]int            // counts before the last text, with Options.RecordDelta
+	documents     int                       // number of processed texts
+	lower         func(string) string       // strings.ToLower, or the casing of Options.Locale
+	collator      *collate.Collator
+	engSplit      *regexp.Regexp
+	chSplit       *regexp.Regexp
+	stale         bool // terms changed since the list was built
 }
 
 type Term struct {
-	Term  string
-	Count int
+	Term      string
+	Count     int
+	Frequency float64  // Count / TotalTokens(), with Options.RelativeFrequency
+	Rank      int      // 1-based position by descending count
+	Language  string   // language the term was found in, "" for AddTerm
+	Absorbed  []string // substrings filtered out in favor of the term, with Options.RecordAbsorbed
+	Romanized string   // romaji of the kana and pinyin of the Han characters, with Options.Romanize
+	Seen      Seen     // first and last texts the term was found in, with Options.RecordSeen
+}
+
+// LanguageTerm returns how a term of a language is given to the methods
+// taking terms when Options.SeparateLanguages is set.
+func LanguageTerm(lang, term string) string {
+	return lang + ":" + term
+}
+
+// Make the Term of a counted term; with Options.SeparateLanguages, the
+// term is stripped of its language.
+func (w *WordFeq) newTerm(term string, count int) Term {
+	lang := w.languages[term]
+	term = w.bareTerm(term)
+	t := Term{Term: term, Count: count, Language: lang}
+	if w.options.Romanize {
+		t.Romanized = w.romanize(term)
+	}
+	t.Seen = w.seen[w.termKey(t)]
+	return t
+}
+
+// The counted term without its language, as in Term.Term.
+func (w *WordFeq) bareTerm(term string) string {
+	if w.options.SeparateLanguages {
+		return strings.TrimPrefix(term, w.languages[term]+":")
+	}
+	return term
+}
+
+// The reverse of newTerm.
+func (w *WordFeq) termKey(t Term) string {
+	if w.options.SeparateLanguages {
+		return LanguageTerm(t.Language, t.Term)
+	}
+	return t.Term
+}
+
+// PerMille returns the relative frequency of the term in per mille.
+func (t Term) PerMille() float64 {
+	return t.Frequency * 1000
 }
 
+var ErrInvalidUTF8 = errors.New("wordfreq: invalid UTF-8 text")
+
 func (w *WordFeq) Process(text string) []Term {
+	return w.ProcessWeighted(text, 1)
+}
+
+// ProcessE is like Process, but reports the texts that cannot be
+// processed, i.e. invalid UTF-8 texts with Options.InvalidUTF8 'error'.
+func (w *WordFeq) ProcessE(text string) ([]Term, error) {
+	err := w.process(text, 1)
+
+	return w.List(), err
+}
+
+// Result of a ProcessResult call.
+type Result struct {
+	Terms   []Term        // the list, as returned by Process
+	Delta   []Term        // the listed terms counted by the call, with Options.RecordDelta
+	Tokens  int           // TotalTokens() after the call
+	Bytes   int           // length of the processed text
+	Elapsed time.Duration // time spent processing the text and listing the terms
+}
+
+// ProcessResult is like ProcessE, returning the run metadata along with
+// the list.
+func (w *WordFeq) ProcessResult(text string) (Result, error) {
+	start := time.Now()
+	err := w.process(text, 1)
+	r := Result{Terms: w.List(), Tokens: w.TotalTokens(), Bytes: len(text)}
+	if w.options.RecordDelta {
+		r.Delta = w.Delta()
+	}
+	r.Elapsed = time.Since(start)
+
+	return r, err
+}
+
+// ProcessBatch processes the documents one after the other, returning
+// every term of each document, whatever MinimumCount, along with the
+// list of all counted terms.
+func (w *WordFeq) ProcessBatch(docs []string) (perDoc [][]Term, total []Term) {
+	s := w.shard()
+	s.options.MinimumCount = 1
+
+	perDoc = make([][]Term, 0, len(docs))
+	for _, doc := range docs {
+		s.process(doc, 1)
+		perDoc = append(perDoc, s.List())
+		w.merge(s)
+		s.Empty()
+	}
+	w.stale = true
+
+	return perDoc, w.List()
+}
+
+// ProcessWithOptions processes the text configured by the non-zero
+// fields of override in place of the options of New, e.g. other phrase
+// lengths or languages, adding its counts to the others. The zero fields
+// keep the options of New: a bool cannot be turned off, and an empty
+// non-nil slice clears one. Nor can the recording of the occurrences,
+// document frequencies or vectors be turned on, missing the earlier
+// documents.
+func (w *WordFeq) ProcessWithOptions(text string, override Options) ([]Term, error) {
+	switch {
+	case override.RecordOccurrences && !w.options.RecordOccurrences:
+		return w.List(), errors.New("wordfreq: RecordOccurrences cannot be turned on by an override")
+	case override.DocumentFrequency && !w.options.DocumentFrequency:
+		return w.List(), errors.New("wordfreq: DocumentFrequency cannot be turned on by an override")
+	case override.DocumentVectors && !w.options.DocumentVectors:
+		return w.List(), errors.New("wordfreq: DocumentVectors cannot be turned on by an override")
+	}
+
+	o, err := New(w.options.overriddenBy(override))
+	if err != nil {
+		return w.List(), err
+	}
+	o.at = w.at
+
+	err = o.process(text, 1)
+	w.merge(o)
+	w.stale = true
+
+	return w.List(), err
+}
+
+// ProcessWeighted processes the text as if it was repeated weight times.
+func (w *WordFeq) ProcessWeighted(text string, weight int) []Term {
+	if weight > 0 {
+		w.process(text, weight)
+	}
+
+	return w.List()
+}
+
+// Add counts the terms of the text like Process, but leaves the list to
+// be regenerated only when it is next read, saving the sort for
+// pipelines adding many texts.
+func (w *WordFeq) Add(text string) {
+	w.process(text, 1)
+}
+
+// ProcessDocument processes each field of a structured document with
+// its weight from weights, defaulting to 1 for the fields not listed.
+func (w *WordFeq) ProcessDocument(doc map[string]string, weights map[string]int) []Term {
+	fields := make([]string, 0, len(doc))
+	for field := range doc {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	w.inDocument = true
+	defer w.endDocument()
+
+	for _, field := range fields {
+		weight, ok := weights[field]
+		if !ok {
+			weight = 1
+		}
+		if weight > 0 {
+			w.process(doc[field], weight)
+		}
+	}
+
+	return w.List()
+}
+
+// Make the text fit for processing, according to the options.
+func (w *WordFeq) prepare(text string) (string, *textOffsets, error) {
+	var offsets *textOffsets
+	if !utf8.ValidString(text) {
+		switch w.options.InvalidUTF8 {
+		case "error":
+			return "", nil, ErrInvalidUTF8
+		case "skip":
+			text, offsets = rewrite(text, offsets, invalidUTF8Edits(text, ""))
+		default:
+			text, offsets = rewrite(text, offsets, invalidUTF8Edits(text, string(utf8.RuneError)))
+		}
+	}
+
+	// the invisible characters would split or contaminate the terms
+	if strings.IndexFunc(text, isInvisible) >= 0 {
+		text, offsets = rewrite(text, offsets, runeEdits(text, func(r rune) (string, bool) {
+			return "", isInvisible(r)
+		}))
+	}
+
+	// full-width ASCII to half-width, half-width katakana to full-width
+	if w.options.FoldWidth && width.Fold.String(text) != text {
+		text, offsets = rewrite(text, offsets, runeEdits(text, foldWidth))
+	}
+
+	// rejoin the words broken by a line break: "infor-\nmation"
+	if w.options.Dehyphenate {
+		edits := make([]edit, 0)
+		for _, m := range lineHyphen.FindAllStringSubmatchIndex(text, -1) {
+			edits = append(edits, edit{m[3], m[4], ""})
+		}
+		text, offsets = rewrite(text, offsets, edits)
+	}
+
+	return text, offsets, nil
+}
+
+// Zero-width characters, byte order marks and control characters but
+// white spaces.
+func isInvisible(r rune) bool {
+	switch r {
+	case '\t', '\n', '\r', '\f', '\v':
+		return false
+	case 0x200B, 0x200C, 0x200D, 0x2060, 0xFEFF:
+		return true
+	}
+	return unicode.IsControl(r)
+}
+
+var lineHyphen = regexp.MustCompile("(\\pL)-[ \t]*\r?\n[ \t]*(\\p{Ll})")
+
+// Count the terms of the text, without regenerating the list.
+func (w *WordFeq) process(original string, weight int) error {
+	start := time.Now()
+	text, offsets, err := w.prepare(original)
+	if err != nil {
+		return err
+	}
+
+	at := w.at
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	var current string // language being processed
+	key := func(term string) string {
+		if w.options.SeparateLanguages {
+			return LanguageTerm(current, term)
+		}
+		return term
+	}
+
+	var before map[string]int // counts before the text, with watchers or Options.RecordDelta
+	if w.watching() || w.options.RecordDelta {
+		before = make(map[string]int)
+	}
 
 	pushTerm := func(term string, count int) {
+		term = key(term)
+		count *= weight
+		if _, ok := before[term]; !ok && before != nil {
+			before[term] = w.terms[term]
+		}
 		if n, ok := w.terms[term]; ok {
 			w.terms[term] = n + count
 		} else {
 			w.terms[term] = count
 		}
+		w.languages[term] = current
+		if w.options.HalfLife > 0 {
+			w.decay.add(term, count, at, w.options.HalfLife)
+		}
+		if w.options.DocumentFrequency || w.options.DocumentVectors {
+			w.docTerms[term] += count
+		}
+		if w.options.RecordSeen {
+			w.see(term, w.documents, at)
+		}
+	}
+
+	pushVariant := func(term, word string, count int) {
+		term = key(term)
+		forms, ok := w.variants[term]
+		if !ok {
+			forms = make(map[string]int)
+			w.variants[term] = forms
+		}
+		forms[word] += count * weight
+	}
+
+	var pushAbsorbed func(string, string, int)
+	if w.options.RecordAbsorbed {
+		pushAbsorbed = func(term, substring string, count int) {
+			term = key(term)
+			if w.absorbed[term] == nil {
+				w.absorbed[term] = make(map[string]int)
+			}
+			w.absorbed[term][substring] += count * weight
+		}
+	}
+
+	var pushNGram func(string, int)
+	if w.options.RecordNGrams {
+		pushNGram = func(ngram string, count int) {
+			w.ngrams[ngram] += count * weight
+		}
+	}
+
+	var occurrences []occurrence
+	var pushOccurrence func(string, int, int)
+	if w.options.Cooccurrence || w.options.RecordOccurrences || w.options.SkipGramWindow > 0 {
+		pushOccurrence = func(term string, start, end int) {
+			occurrences = append(occurrences, occurrence{key(term), start, end})
+		}
 	}
 
 	for _, lang := range w.options.Languages {
+		current = lang
 		switch lang {
 		case "english":
-			processEnglish(text, w.options.StopWords, pushTerm)
+			from := len(occurrences)
+			w.processEnglish(text, pushTerm, pushVariant, pushOccurrence)
+			if w.options.SkipGramWindow > 0 {
+				w.countSkipGrams(occurrences[from:], weight)
+			}
+			if w.options.ExtractKeywords {
+				w.rake.process(text, w.stopWords["english"], weight)
+			}
 			break
 		case "chinese":
-			processChinese(text, w.options.StopWords, w.options.MaxiumPhraseLength, w.options.NoFilterSubstring, pushTerm)
+			w.processChinese(text, pushTerm, pushAbsorbed, pushNGram, pushOccurrence)
 			break
+		case "characters":
+			w.processCharacters(text, pushTerm, pushOccurrence)
+		}
+	}
+
+	if w.options.Cooccurrence {
+		w.countCooccurrences(text, occurrences, weight)
+	}
+
+	if w.options.RecordOccurrences {
+		w.recordOccurrences(occurrences, offsets)
+		w.texts = append(w.texts, original)
+	}
+
+	w.documents++
+	w.stale = true
+	if !w.inDocument {
+		w.endDocument()
+	}
+	w.notify(before)
+	if w.options.RecordDelta {
+		w.delta = before
+	}
+
+	if w.options.Metrics != nil {
+		w.options.Metrics.ObserveText(len(text), time.Since(start))
+		w.options.Metrics.SetTerms(len(w.terms))
+	}
+
+	return nil
+}
+
+// SetMinimumCount changes the minimal count required to be listed,
+// taking effect the next time the list is read.
+func (w *WordFeq) SetMinimumCount(n int) {
+	if n <= 0 {
+		n = 2
+	}
+	w.options.MinimumCount = n
+	w.stale = true
+}
+
+// Rebuild regenerates the sorted list from the counted terms, without
+// processing any text.
+func (w *WordFeq) Rebuild() []Term {
+	w.rebuild()
+
+	return w.List()
+}
+
+// Regenerate the sorted list if the terms changed since it was built.
+func (w *WordFeq) sync() {
+	if w.stale {
+		w.rebuild()
+	}
+}
+
+// Return the Chinese terms with the same count as a longer term
+// containing them, up to Options.SubstringRatio, over all the texts
+// processed, with these longer terms.
+func (w *WordFeq) absorbedSubstrings() map[string][]string {
+	absorbed := make(map[string][]string)
+	buf := make([]string, 0)
+	for key, termCount := range w.terms {
+		if w.languages[key] != "chinese" {
+			continue
+		}
+
+		term := w.newTerm(key, termCount).Term
+		buf = appendSubStrings(buf[:0], term, w.options.MaxiumPhraseLength, 0)
+		for _, substring := range buf {
+			if substring == term || isStopWord(substring, w.options.ProtectedPhrases) {
+				continue
+			}
+			subKey := substring
+			if w.options.SeparateLanguages {
+				subKey = LanguageTerm("chinese", substring)
+			}
+			if w.languages[subKey] == "chinese" && w.options.absorbs(termCount, w.terms[subKey]) {
+				absorbed[subKey] = append(absorbed[subKey], key)
+			}
+		}
+	}
+	return absorbed
+}
+
+// Return the substrings absorbed by the term within the texts, and
+// across them, sorted.
+func (w *WordFeq) absorbedBy(term string, across []string) []string {
+	substrings := make(map[string]bool)
+	for substring := range w.absorbed[term] {
+		substrings[substring] = true
+	}
+	for _, substring := range across {
+		substrings[substring] = true
+	}
+
+	list := make([]string, 0, len(substrings))
+	for substring := range substrings {
+		list = append(list, substring)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// Regenerate the sorted list from the terms.
+func (w *WordFeq) rebuild() {
+	w.stale = false
+
+	total := 0
+	if w.options.RelativeFrequency {
+		total = w.TotalTokens()
+	}
+
+	var absorbed map[string][]string
+	if w.options.FilterAcrossTexts && !w.options.NoFilterSubstring {
+		absorbed = w.absorbedSubstrings()
+	}
+
+	// term -> substrings absorbed across the texts
+	absorbing := make(map[string][]string)
+	if w.options.RecordAbsorbed {
+		for subKey, terms := range absorbed {
+			for _, term := range terms {
+				absorbing[term] = append(absorbing[term], w.newTerm(subKey, 0).Term)
+			}
 		}
 	}
 
 	w.list = w.list[:0]
 	for term, termCount := range w.terms {
-		if termCount < w.options.MinimumCount {
+		if _, ok := absorbed[term]; termCount < w.options.MinimumCount || ok {
 			continue
 		}
-		w.list = append(w.list, Term{term, termCount})
+		t := w.newTerm(term, termCount)
+		if w.options.RecordAbsorbed {
+			t.Absorbed = w.absorbedBy(term, absorbing[term])
+		}
+		if total > 0 {
+			t.Frequency = float64(termCount) / float64(total)
+		}
+		w.list = append(w.list, t)
 	}
 	sort.Sort(byTerm(w.list))
 
-	return w.list
+	w.ranks = make(map[string]int, len(w.list))
+	for i := range w.list {
+		w.list[i].Rank = i + 1
+		w.ranks[w.termKey(w.list[i])] = i + 1
+	}
+
+	if w.options.SortBy != SortByCountDesc {
+		w.sortTerms(w.list, w.options.SortBy)
+	}
 }
 
 func (w *WordFeq) Empty() {
 	w.list = w.list[:0]
+	w.ranks = make(map[string]int)
+	w.stale = false
 	w.terms = make(map[string]int)
+	w.rake = newRakeStats()
+	w.cooccurrences = make(map[string]map[string]int)
+	w.occurrences = make(map[string][]Span)
+	w.variants = make(map[string]map[string]int)
+	w.languages = make(map[string]string)
+	w.docFreq = make(map[string]int)
+	w.absorbed = make(map[string]map[string]int)
+	w.docTerms = make(map[string]int)
+	w.vectors = nil
+	w.dfDocuments = 0
+	w.filtered = FilteredStats{}
+	w.decay = newDecayStats()
+	w.texts = nil
+	w.ngrams = make(map[string]int)
+	w.skipGrams = make(map[[2]string]int)
+	w.seen = make(map[string]Seen)
+	w.delta = nil
+	w.documents = 0
+}
+
+// List returns a copy of the sorted list.
+func (w *WordFeq) List() []Term {
+	w.sync()
+	return w.AppendList(make([]Term, 0, len(w.list)))
 }
 
-func (w WordFeq) List() []Term {
+// AppendList appends the sorted list to dst and returns the result,
+// letting callers reuse their own buffer.
+func (w *WordFeq) AppendList(dst []Term) []Term {
+	w.sync()
+	return append(dst, w.list...)
+}
+
+// ListUnsafe returns the internal sorted list without copying. It must
+// not be modified, and is overwritten when the list is regenerated.
+func (w *WordFeq) ListUnsafe() []Term {
+	w.sync()
 	return w.list
 }
 
+// All yields the listed terms with their counts, in list order.
+func (w *WordFeq) All() iter.Seq2[string, int] {
+	return func(yield func(string, int) bool) {
+		w.sync()
+		for _, t := range w.list {
+			if !yield(t.Term, t.Count) {
+				return
+			}
+		}
+	}
+}
+
+// Rank returns the 1-based position of the term in the list sorted by
+// descending count, or 0 if it is not listed.
+func (w *WordFeq) Rank(term string) int {
+	w.sync()
+	return w.ranks[term]
+}
+
 type byTerm []Term
 
 func (s byTerm) Len() int {
@@ -113,6 +988,9 @@ func (s byTerm) Less(i, j int) bool {
 	t1 := s[i]
 	t2 := s[j]
 	if t1.Count == t2.Count {
+		if t1.Term == t2.Term {
+			return t1.Language < t2.Language
+		}
 		return t1.Term < t2.Term
 	} else {
 		return t1.Count > t2.Count
@@ -120,66 +998,379 @@ func (s byTerm) Less(i, j int) bool {
 }
 
 type stemWord struct {
-	Word  string
-	Count int
+	Word     string
+	Count    int
+	Variants map[string]int // word -> count
 }
 
+// Default regular expression matching the characters between English
+// words, i.e. that do not belong to a word.
+const EnglishSplitPattern = "[^A-Za-zéÉ'’_\\-0-9@\\.]+"
+
 var (
-	engSplit = regexp.MustCompile("[^A-Za-zéÉ'’_\\-0-9@\\.]+")
-	engR1    = regexp.MustCompile("\\.+")                      // replace multiple full stops
-	engR2    = regexp.MustCompile("(.{3,})\\.$")               // replace single trailing stop
-	engR3    = regexp.MustCompile("(?i)n[\\'’]t\b")            // get rid of ~n't
-	engR4    = regexp.MustCompile("(?i)[\\'’](s|ll|d|ve)?\\b") // get rid of ’ and '
-	engTest  = regexp.MustCompile("^[0-9\\.@\\-]+$")
+	engR3   = regexp.MustCompile("(?i)n[\\'’]t\b") // get rid of ~n't
+	engTest = regexp.MustCompile("^[0-9\\.@\\-]+$")
+
+	engCompound = regexp.MustCompile("^[\\pL\\pN]+(-[\\pL\\pN]+)+$")
+	engAcronym  = regexp.MustCompile("^(\\pL\\.){2,}$")
+	engNumber   = regexp.MustCompile("^[0-9]+(\\.[0-9]+)*$")
+	engMeasure  = regexp.MustCompile("^[0-9]+(\\.[0-9]+)*[A-Za-z]*$")
 )
 
-func processEnglish(text string, stopWords []string, pushTerm func(string, int)) {
+// Units merged with the numbers preceding them, with Options.KeepNumbers.
+var units = map[string]bool{
+	"mg": true, "g": true, "kg": true, "t": true, "oz": true, "lb": true, "lbs": true,
+	"mm": true, "cm": true, "m": true, "km": true, "ft": true, "mi": true,
+	"ml": true, "cl": true, "l": true, "tsp": true, "tbsp": true,
+	"ms": true, "s": true, "sec": true, "min": true, "h": true, "hr": true, "hrs": true,
+	"kb": true, "mb": true, "gb": true, "tb": true,
+	"hz": true, "khz": true, "mhz": true, "ghz": true,
+	"mv": true, "v": true, "kv": true, "ma": true, "mah": true, "w": true, "kw": true, "kwh": true,
+	"mol": true, "mmol": true, "ppm": true,
+}
+
+// A normalized word of an English text, with the [start, end) byte
+// offsets of its token.
+type englishWord struct {
+	word       string
+	start, end int
+	kept       bool // taken as is: names and abbreviations
+	expanded   bool // of a contraction, counted even if a stop word: "do", "not"
+}
+
+// Split the text into normalized words.
+func (w *WordFeq) englishWords(text string) []englishWord {
+	words := make([]englishWord, 0)
+
+	// say bye bye to characters that is not belongs to a word
+	for _, span := range splitIndex(w.engSplit, text) {
+		if token := text[span[0]:span[1]]; w.isAbbreviation(token) {
+			words = append(words, englishWord{token, span[0], span[1], true, false})
+			continue
+		}
+
+		// "don't" -> "do not", every word spanning the token, kept
+		// whatever its length
+		if expanded := w.expandContraction(text[span[0]:span[1]]); expanded != nil {
+			for _, word := range expanded {
+				words = append(words, englishWord{word, span[0], span[1], true, true})
+			}
+			continue
+		}
+
+		word := normalizeEnglishWord(text[span[0]:span[1]])
+
+		if word == "" {
+			continue
+		}
+		if engCompound.MatchString(word) && !engTest.MatchString(word) {
+			words = w.appendCompound(words, text, span, word)
+			continue
+		}
+		words = append(words, englishWord{word, span[0], span[1], false, false})
+		if w.lexicon != nil {
+			words = w.appendLexiconParts(words, text, span, word)
+		}
+	}
+
+	if w.options.KeepNumbers {
+		// merge the numbers with the units following them: "5 kg" -> "5kg"
+		merged := words[:0]
+		for i := 0; i < len(words); i++ {
+			word := words[i]
+			if i+1 < len(words) && engNumber.MatchString(word.word) {
+				next := words[i+1]
+				if units[strings.ToLower(next.word)] && strings.TrimSpace(text[word.end:next.start]) == "" {
+					word = englishWord{word.word + next.word, word.start, next.end, false, false}
+					i++
+				}
+			}
+			merged = append(merged, word)
+		}
+		words = merged
+	}
+
+	if w.options.KeepNames {
+		words = w.mergeNames(text, words)
+	}
+	return words
+}
+
+// Append a hyphenated compound (state-of-the-art) according to the
+// Options.HyphenMode: 'compound' keeps it whole and unstemmed, 'split'
+// splits it into its words, and 'both' does both.
+func (w *WordFeq) appendCompound(words []englishWord, text string, span []int, word string) []englishWord {
+	if w.options.HyphenMode != "split" {
+		words = append(words, englishWord{word, span[0], span[1], true, false})
+	}
+	if w.options.HyphenMode == "compound" {
+		return words
+	}
+
+	token := text[span[0]:span[1]]
+	from := 0
+	for _, part := range strings.Split(word, "-") {
+		start, end := span[0], span[1]
+		if i := strings.Index(token[from:], part); i >= 0 {
+			start = span[0] + from + i
+			end = start + len(part)
+			from += i + len(part)
+		}
+		words = append(words, englishWord{part, start, end, false, false})
+	}
+	return words
+}
+
+// Merge the runs of capitalized words of a sentence separated by spaces
+// only into names: "New York", "Taylor Swift". A stop word does not start a name,
+// "The Beatles" -> "Beatles".
+func (w *WordFeq) mergeNames(text string, words []englishWord) []englishWord {
+	capitalized := func(word englishWord) bool {
+		r, _ := utf8.DecodeRuneInString(word.word)
+		return unicode.IsUpper(r) && !isStopWordFold(word.word, w.stopWords["english"])
+	}
+
+	// only spaces between, and no full stop ending the first; the parts
+	// of compounds lie within the compound, not after it
+	joined := func(first, second englishWord) bool {
+		return second.start >= first.end && text[first.end-1] != '.' && strings.Trim(text[first.end:second.start], " \t") == ""
+	}
+
+	merged := words[:0]
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		j := i + 1
+		for capitalized(word) && j < len(words) && capitalized(words[j]) && joined(words[j-1], words[j]) {
+			j++
+		}
+
+		if j > i+1 {
+			parts := make([]string, 0, j-i)
+			for _, part := range words[i:j] {
+				parts = append(parts, part.word)
+			}
+			word = englishWord{strings.Join(parts, " "), word.start, words[j-1].end, true, false}
+			i = j - 1
+		}
+		merged = append(merged, word)
+	}
+	return merged
+}
+
+// Whether the token is an abbreviation to keep as is, with
+// Options.KeepAbbreviations: acronyms (U.S.A.) and the listed ones (e.g.).
+func (w *WordFeq) isAbbreviation(token string) bool {
+	if w.abbreviations == nil || !strings.HasSuffix(token, ".") {
+		return false
+	}
+	return engAcronym.MatchString(token) || w.abbreviations[w.lower(strings.Replace(token, ".", "", -1))]
+}
+
+// Contractions expanded with Options.ExpandContractions, lower case.
+var (
+	contractions = map[string][]string{
+		"won't": {"will", "not"}, "can't": {"can", "not"}, "shan't": {"shall", "not"},
+		"ain't": {"is", "not"}, "let's": {"let", "us"},
+	}
+	contractionSuffixes = []struct {
+		suffix   string
+		expanded string
+	}{
+		{"n't", "not"}, {"'re", "are"}, {"'ll", "will"}, {"'ve", "have"},
+		{"'d", "would"}, {"'m", "am"}, {"'s", "is"},
+	}
+	// the words "'s" stands for "is" after, a possessive otherwise
+	isContracted = map[string]bool{
+		"it": true, "he": true, "she": true, "that": true, "what": true, "there": true,
+		"here": true, "who": true, "where": true, "how": true,
+	}
+)
+
+// Return the words of the contraction, or nil if the token is none.
+func (w *WordFeq) expandContraction(token string) []string {
+	if !w.options.ExpandContractions || !strings.ContainsAny(token, "'’") {
+		return nil
+	}
+	token = strings.Replace(token, "’", "'", -1)
+
+	lower := strings.ToLower(token)
+	if expanded, ok := contractions[lower]; ok {
+		return expanded
+	}
+	for _, c := range contractionSuffixes {
+		base := strings.TrimSuffix(lower, c.suffix)
+		if base == lower || base == "" || (c.suffix == "'s" && !isContracted[base]) {
+			continue
+		}
+		return []string{token[:len(base)], c.expanded}
+	}
+	return nil
+}
+
+// Collapse the runs of full stops of a word, drop its trailing full stop
+// after three characters or more, then drop its contractions.
+func normalizeEnglishWord(word string) string {
+	if strings.IndexByte(word, '.') >= 0 {
+		b := make([]byte, 0, len(word))
+		for i := 0; i < len(word); i++ {
+			if word[i] == '.' && i > 0 && word[i-1] == '.' {
+				continue
+			}
+			b = append(b, word[i])
+		}
+
+		// three characters, not line breaks, right before the stop
+		if n := len(b); n > 0 && b[n-1] == '.' {
+			head, runes := b[:n-1], 0
+			for runes < 3 && len(head) > 0 {
+				r, size := utf8.DecodeLastRune(head)
+				if r == '\n' {
+					break
+				}
+				head = head[:len(head)-size]
+				runes++
+			}
+			if runes == 3 {
+				b = b[:n-1]
+			}
+		}
+		word = string(b)
+	}
+
+	return dropContractions(word)
+}
+
+// Drop the apostrophes of a word, with the s, ll, d or ve ending the
+// word after them: "it's" -> "it", "o'clock" -> "oclock".
+func dropContractions(word string) string {
+	// engR3 ends with a Go "\b", a backspace, not a word boundary
+	if strings.IndexByte(word, '\b') >= 0 {
+		word = engR3.ReplaceAllString(word, "")
+	}
+	if !strings.ContainsAny(word, "'’") {
+		return word
+	}
+
+	b := make([]byte, 0, len(word))
+	for i := 0; i < len(word); {
+		size := 0
+		if word[i] == '\'' {
+			size = 1
+		} else if strings.HasPrefix(word[i:], "’") {
+			size = len("’")
+		}
+		if size == 0 {
+			b = append(b, word[i])
+			i++
+			continue
+		}
+
+		if n := contractionLength(word[i+size:]); n >= 0 {
+			i += size + n
+		} else {
+			b = append(b, word[i:i+size]...)
+			i += size
+		}
+	}
+	return string(b)
+}
+
+// The length of the contraction starting rest, right after an
+// apostrophe, or -1 if the apostrophe is not to be dropped.
+func contractionLength(rest string) int {
+	for _, suffix := range []string{"s", "ll", "d", "ve"} {
+		n := len(suffix)
+		if len(rest) >= n && strings.EqualFold(rest[:n], suffix) && (len(rest) == n || !isWordByte(rest[n])) {
+			return n
+		}
+	}
+
+	// the apostrophe alone, before a word
+	if len(rest) > 0 && isWordByte(rest[0]) {
+		return 0
+	}
+	return -1
+}
+
+// Whether c is an ASCII word character, as in \w.
+func isWordByte(c byte) bool {
+	return c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func (w *WordFeq) processEnglish(text string, pushTerm func(string, int), pushVariant func(string, string, int), pushOccurrence func(string, int, int)) {
+	minLength, maxLength := w.options.termLengths("english")
 
 	// For English, we count "stems" instead of words,
 	// and decide how to represent that stem at the end
 	// according to the counts.
 	stems := make(map[string]*stemWord)
 
-	// say bye bye to characters that is not belongs to a word
-	words := engSplit.Split(text, -1)
+	// (stem, start, end) of each counted word, only kept when
+	// the caller wants to know where the terms are
+	type stemSpan struct {
+		stem       string
+		start, end int
+	}
+	spans := make([]stemSpan, 0)
 
-	for _, word := range words {
-		word = engR1.ReplaceAllString(word, ".")
-		word = engR2.ReplaceAllString(word, "$1")
-		word = engR3.ReplaceAllString(word, "")
-		word = engR4.ReplaceAllString(word, "")
+	for _, ew := range w.englishWords(text) {
+		word := ew.word
+
+		// numbers (and measures) are kept as they are when asked to,
+		// and so are names and abbreviations
+		number := w.options.KeepNumbers && engMeasure.MatchString(word) || ew.kept
 
-		// skip if the word is shorter than two characters
-		// (i.e. exactly one letter)
-		if utf8.RuneCountInString(word) <= 2 {
+		// skip if the word is too short (by default, two letters
+		// or less) or too long
+		if n := utf8.RuneCountInString(word); !number && (n < minLength || (maxLength > 0 && n > maxLength)) {
+			w.filtered.Length++
 			continue
 		}
 
-		if engTest.MatchString(word) {
+		if !number && engTest.MatchString(word) {
+			w.filtered.Numbers++
 			continue
 		}
 
-		// stopwords test
-		ok := true
-		for _, stopWord := range stopWords {
-			if stopWord == word {
-				ok = false
-				break
-			}
+		// stopwords test, "The" is a stop word too unless asked otherwise
+		if !ew.expanded && w.isEnglishStopWord(word) {
+			w.filtered.StopWords++
+			continue
 		}
-		if !ok {
+
+		stem := w.lower(word)
+		if !number {
+			stem = w.stems.stem(stem)
+		}
+
+		// colour -> color, organising -> organizing
+		if spelling, ok := w.spellings[stem]; ok {
+			stem = spelling
+		}
+
+		if w.vocabulary != nil && !w.vocabulary[stem] {
+			w.filtered.Vocabulary++
 			continue
 		}
 
-		stem := strings.ToLower(porterstemmer.StemString(word))
+		// keep the differently cased words apart (Apple, apple),
+		// but Apple and Apples together
+		if w.options.CaseSensitive {
+			stem = wordCase(word) + stem
+		}
 
 		// count++ for the stem
 		wc, ok := stems[stem]
 		if !ok {
-			wc = &stemWord{word, 0}
+			wc = &stemWord{word, 0, make(map[string]int)}
 			stems[stem] = wc
 		}
 		wc.Count += 1
+		wc.Variants[word]++
+
+		if pushOccurrence != nil {
+			spans = append(spans, stemSpan{stem, ew.start, ew.end})
+		}
 
 		// if the current word representing the stem is longer than
 		// this one, use this word instead (booking -> book)
@@ -188,120 +1379,449 @@ func processEnglish(text string, stopWords []string, pushTerm func(string, int))
 		}
 
 		// if the current word representing the stem is of the same
-		// length but with different form,
-		// use the lower-case representation (Book -> book)
+		// length but with different form, use the lexically smallest
+		// lower-case representation, whatever the order of the words
+		// (Book -> book, agreed/agrees -> agreed)
 		if utf8.RuneCountInString(word) == utf8.RuneCountInString(wc.Word) &&
 			word != wc.Word {
-			wc.Word = strings.ToLower(word)
+			if !w.options.CaseSensitive {
+				word = w.lower(word)
+				wc.Word = w.lower(wc.Word)
+			}
+			if word < wc.Word {
+				wc.Word = word
+			}
 		}
 	}
 
 	// Push each "stem" into terms as word
 	for _, stem := range stems {
 		pushTerm(stem.Word, stem.Count)
+		for word, n := range stem.Variants {
+			pushVariant(stem.Word, word, n)
+		}
+	}
+
+	for _, span := range spans {
+		pushOccurrence(stems[span.stem].Word, span.start, span.end)
+	}
+}
+
+// Return the lower casing of Options.Locale, or strings.ToLower without
+// locale. A caser is stateful, each shard needs its own.
+func newLower(locale string) (func(string) string, error) {
+	if locale == "" {
+		return strings.ToLower, nil
+	}
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return nil, err
 	}
+	return cases.Lower(tag).String, nil
 }
 
+// Return the collator of Options.CollationLocale, nil for the byte order.
+// A collator is stateful too.
+func newCollator(locale string) (*collate.Collator, error) {
+	if locale == "" {
+		return nil, nil
+	}
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return nil, err
+	}
+	return collate.New(tag), nil
+}
+
+// Whether the word is an English stop word, "The" too unless
+// Options.ExactStopWords.
+func (w *WordFeq) isEnglishStopWord(word string) bool {
+	stopWords := w.stopWords["english"]
+	switch {
+	case w.options.ExactStopWords:
+		return isStopWord(word, stopWords)
+	case w.options.Locale == "":
+		return isStopWordFold(word, stopWords)
+	}
+
+	word = w.lower(word)
+	for _, stopWord := range stopWords {
+		if w.lower(stopWord) == word {
+			return true
+		}
+	}
+	return false
+}
+
+// Classify the casing of a word: lower, title, upper or mixed case
+// (iPhone).
+func wordCase(word string) string {
+	switch word {
+	case strings.ToLower(word):
+		return "a:"
+	case strings.ToUpper(word):
+		return "A:"
+	}
+
+	r, size := utf8.DecodeRuneInString(word)
+	if unicode.IsUpper(r) && word[size:] == strings.ToLower(word[size:]) {
+		return "Aa:"
+	}
+	return "aA:"
+}
+
+const (
+	hanClass  = "\u4E00-\u9FFF\u3400-\u4DBF"
+	kanaClass = "\u3041-\u309F\u30A0-\u30FF"
+)
+
 var (
-	chReplace = regexp.MustCompile("[^\u4E00-\u9FFF\u3400-\u4DBF]+")
+	chReplace = regexp.MustCompile("[^" + hanClass + "]+")
 	chTest    = regexp.MustCompile("^[\u4E00-\u9FFF\u3400-\u4DBF]+$")
-	chLines   = regexp.MustCompile("\n+")
 )
 
-func processChinese(text string, stopWords []string, maxPhrashLength int, noFilterSubstring bool, pushTerm func(string, int)) {
+func (w *WordFeq) processChinese(text string, pushTerm func(string, int), pushAbsorbed func(string, string, int), pushNGram func(string, int), pushOccurrence func(string, int, int)) {
 	// Chinese is a language without word boundary.
 	// We must use N-gram here to extract meaningful terms.
+	minLength, maxPhrashLength := w.options.termLengths("chinese")
 
-	// say good bye to non-Chinese (Kanji) characters
-	// TBD: Cannot match CJK characters beyond BMP,
-	// e.g. \u20000-\u2A6DF at plane B.
-
-	// Han: \u4E00-\u9FFF\u3400-\u4DBF
-	// Kana: \u3041-\u309f\u30a0-\u30ff
-	text = chReplace.ReplaceAllString(text, "\n")
-
-	// Use the stop words as separators -- replace them.
-	for _, stopWord := range stopWords {
-		// Not handling that stop word if it's not a Chinese word.
-		if !chTest.MatchString(stopWord) {
-			continue
+	// only the phrases of the vocabulary, no need for N-grams
+	if len(w.options.Vocabulary) > 0 {
+		phrases := countPhrases(text, w.options.Vocabulary)
+		for phrase, n := range phrases {
+			pushTerm(phrase, n)
 		}
-
-		text = strings.Replace(text, stopWord, stopWord+"\n", -1)
+		if pushOccurrence != nil {
+			locatePhrases(text, phrases, pushOccurrence)
+		}
+		return
 	}
 
-	chunks := chLines.Split(text, -1)
+	chunks, removed := chineseChunks(text, w.chSplit, w.options.ChineseBoundaries, w.stopWords["chinese"], w.options.StopWordMode)
+	w.filtered.StopWords += removed
 	pendingTerms := make(map[string]int)
+	in := make(interner)
+
+	buf := substringsPool.Get().(*[]string)
+	defer putSubstrings(buf)
 
 	// counts all the chunks (and it's substrings) in pendingTerms
-	for _, chunk := range chunks {
-		if utf8.RuneCountInString(chunk) <= 1 {
+	for _, span := range chunks {
+		chunk := text[span[0]:span[1]]
+		if utf8.RuneCountInString(chunk) < minLength && pushNGram == nil {
 			continue
 		}
 
-		substrings := getAllSubStrings(chunk, maxPhrashLength)
-		for _, substring := range substrings {
-			if utf8.RuneCountInString(substring) <= 1 {
-				continue
+		// split a long chunk in pieces overlapping by a phrase, the
+		// substrings starting in the overlap being counted with the
+		// next piece
+		for chunk != "" {
+			piece, next := chunk, ""
+			if end := runeOffset(chunk, w.options.MaxChunkLength); end < len(chunk) {
+				piece = chunk[:end+runeOffset(chunk[end:], maxPhrashLength)]
+				next = chunk[end:]
 			}
+			chunk = next
 
-			if n, ok := pendingTerms[substring]; !ok {
-				pendingTerms[substring] = 1
-			} else {
-				pendingTerms[substring] = n + 1
+			*buf = appendSubStrings((*buf)[:0], piece, maxPhrashLength, w.options.MaxChunkLength)
+			for _, substring := range *buf {
+				// the raw counts, of every length, before the filter
+				if pushNGram != nil {
+					pushNGram(in.intern(substring), 1)
+				}
+
+				if utf8.RuneCountInString(substring) < minLength {
+					continue
+				}
+
+				// the substrings share the memory of the text, keep a copy
+				substring = in.intern(substring)
+				if n, ok := pendingTerms[substring]; !ok {
+					pendingTerms[substring] = 1
+				} else {
+					pendingTerms[substring] = n + 1
+				}
 			}
 		}
 	}
 
+	// the protected phrases are counted as a whole, whatever their
+	// length and the chunks they span
+	protected := countPhrases(text, w.options.ProtectedPhrases)
+	for phrase, n := range protected {
+		pendingTerms[phrase] = n
+	}
+
 	// if filterSubstring is true, remove the substrings with the exact
 	// same count as the longer term (implying they are only present in
-	// the longer terms)
-	if !noFilterSubstring {
+	// the longer terms), or nearly with SubstringRatio; left to rebuild
+	// with FilterAcrossTexts
+	if !w.options.NoFilterSubstring && !w.options.FilterAcrossTexts {
+		// decided on the counts of all the terms before deleting any,
+		// the absorption is not transitive below a ratio of 1
+		absorbed := make(map[string]bool)
 		for term, termCount := range pendingTerms {
-			var substrings = getAllSubStrings(term, maxPhrashLength)
-			for _, substring := range substrings {
-				if term == substring {
+			*buf = appendSubStrings((*buf)[:0], term, maxPhrashLength, 0)
+			for _, substring := range *buf {
+				if term == substring || protected[substring] > 0 {
 					continue
 				}
 
 				if subTermCount, ok := pendingTerms[substring]; ok {
-					if subTermCount == termCount {
-						delete(pendingTerms, substring)
+					if w.options.absorbs(termCount, subTermCount) {
+						absorbed[substring] = true
+						if pushAbsorbed != nil {
+							pushAbsorbed(term, substring, subTermCount)
+						}
 					}
 				}
 			}
 		}
+		for substring := range absorbed {
+			delete(pendingTerms, substring)
+		}
 	}
 
 	// add the pendingTerms into terms
 	for term, termCount := range pendingTerms {
 		pushTerm(term, termCount)
 	}
+
+	if pushOccurrence == nil {
+		return
+	}
+
+	// locate every occurrence of the surviving terms
+	for _, span := range chunks {
+		chunk := text[span[0]:span[1]]
+		for i := range chunk {
+			for j, n := i, 0; j < len(chunk) && n < maxPhrashLength; n++ {
+				_, size := utf8.DecodeRuneInString(chunk[j:])
+				j += size
+				if n+1 < minLength {
+					continue
+				}
+
+				if _, ok := pendingTerms[chunk[i:j]]; ok && protected[chunk[i:j]] == 0 {
+					pushOccurrence(chunk[i:j], span[0]+i, span[0]+j)
+				}
+			}
+		}
+	}
+
+	locatePhrases(text, protected, pushOccurrence)
+}
+
+// Count the Chinese phrases found in the text.
+func countPhrases(text string, phrases []string) map[string]int {
+	counts := make(map[string]int)
+	for _, phrase := range phrases {
+		if !chTest.MatchString(phrase) {
+			continue
+		}
+		if n := strings.Count(text, phrase); n > 0 {
+			counts[phrase] = n
+		}
+	}
+	return counts
+}
+
+func locatePhrases(text string, phrases map[string]int, pushOccurrence func(string, int, int)) {
+	for phrase := range phrases {
+		for i := 0; ; {
+			n := strings.Index(text[i:], phrase)
+			if n < 0 {
+				break
+			}
+			i += n
+			pushOccurrence(phrase, i, i+len(phrase))
+			i += len(phrase)
+		}
+	}
+}
+
+// Default punctuation ending the Chinese clauses, no phrase spanning
+// them.
+const ChineseBoundaries = "。，、；：！？「」『』（）《》〈〉【】〔〕“”‘’…—·．,.;:!?()[]\"'"
+
+// Return the [start, end) byte offsets of the Chinese chunks of the text,
+// i.e. runs of Han characters (or of the characters chSplit does not
+// match) within a clause, split around the stop words according to the
+// Options.StopWordMode, with the number of stop words removed.
+func chineseChunks(text string, chSplit *regexp.Regexp, boundaries string, stopWords []string, mode string) ([][]int, int) {
+	// say good bye to non-Chinese (Kanji) characters, and kana unless
+	// Options.IncludeKana
+	// TBD: Cannot match CJK characters beyond BMP,
+	// e.g. \u20000-\u2A6DF at plane B.
+
+	// Han: \u4E00-\u9FFF\u3400-\u4DBF
+	// Kana: \u3041-\u309f\u30a0-\u30ff
+	chunks := make([][]int, 0)
+	removed := 0
+	for _, clause := range splitFuncIndex(text, func(r rune) bool {
+		return strings.ContainsRune(boundaries, r)
+	}) {
+		for _, span := range splitIndex(chSplit, text[clause[0]:clause[1]]) {
+			span[0] += clause[0]
+			span[1] += clause[0]
+			split, n := splitStopWords(text, span, stopWords, mode)
+			chunks = append(chunks, split...)
+			removed += n
+		}
+	}
+	return chunks, removed
+}
+
+// Split the span of a Han run around the Chinese stop words in it:
+// 'remove' drops them, 'split' makes them chunks of their own, and
+// 'keep' leaves them at the end of the chunk before. Returns the number
+// of stop words removed as well.
+func splitStopWords(text string, span []int, stopWords []string, mode string) ([][]int, int) {
+	chunk := text[span[0]:span[1]]
+
+	// the [start, end) offsets of the stop words
+	cuts := make([][]int, 0)
+	for _, stopWord := range stopWords {
+		for i := 0; ; {
+			n := strings.Index(chunk[i:], stopWord)
+			if n < 0 {
+				break
+			}
+			cuts = append(cuts, []int{i + n, i + n + len(stopWord)})
+			i += n + len(stopWord)
+		}
+	}
+	sort.Slice(cuts, func(i, j int) bool {
+		return cuts[i][0] < cuts[j][0]
+	})
+
+	chunks := make([][]int, 0, len(cuts)+1)
+	start, removed := 0, 0
+	for _, cut := range cuts {
+		if cut[1] <= start {
+			continue
+		}
+		if mode == "keep" {
+			chunks = append(chunks, []int{span[0] + start, span[0] + cut[1]})
+			start = cut[1]
+			continue
+		}
+
+		if cut[0] > start {
+			chunks = append(chunks, []int{span[0] + start, span[0] + cut[0]})
+			start = cut[0]
+		}
+		if mode == "split" {
+			chunks = append(chunks, []int{span[0] + start, span[0] + cut[1]})
+		} else {
+			removed++
+		}
+		start = cut[1]
+	}
+	if start < len(chunk) {
+		chunks = append(chunks, []int{span[0] + start, span[1]})
+	}
+	return chunks, removed
+}
+
+// Return the [start, end) byte offsets of the non-empty parts of the
+// text between the runes matching f.
+func splitFuncIndex(text string, f func(rune) bool) [][]int {
+	spans := make([][]int, 0)
+	start := 0
+	for i, r := range text {
+		if f(r) {
+			if i > start {
+				spans = append(spans, []int{start, i})
+			}
+			start = i + utf8.RuneLen(r)
+		}
+	}
+	if start < len(text) {
+		spans = append(spans, []int{start, len(text)})
+	}
+	return spans
+}
+
+// Like regexp.Split, but returns the [start, end) byte offsets of
+// the pieces instead of the pieces themselves.
+func splitIndex(re *regexp.Regexp, text string) [][]int {
+	spans := make([][]int, 0)
+	start := 0
+	for _, sep := range re.FindAllStringIndex(text, -1) {
+		spans = append(spans, []int{start, sep[0]})
+		start = sep[1]
+	}
+	return append(spans, []int{start, len(text)})
 }
 
 // Return all possible substrings of a give string in an array
 // If there is no maxLength is unrestricted, array will contain
 // (2 * str.length) substrings.
 func getAllSubStrings(str string, maxLength int) []string {
-	runes := []rune(str)
-	result := make([]string, 0, 2*len(runes))
-	i := maxLength
-	c := len(runes)
-	if c < maxLength {
-		i = c
-	}
+	return appendSubStrings(make([]string, 0, 2*utf8.RuneCountInString(str)), str, maxLength, 0)
+}
 
-	for ; i >= 1; i-- {
-		result = append(result, string(runes[0:i]))
+// Append the substrings of str to result, as slices of str, longest
+// first for each start, stopping after the given number of starts when
+// positive. Iterative, as chunks may be long.
+func appendSubStrings(result []string, str string, maxLength int, starts int) []string {
+	if starts <= 0 {
+		starts = len(str)
 	}
+	for start := 0; start < len(str) && starts > 0; starts-- {
+		rest := str[start:]
+		for end := runeOffset(rest, maxLength); end > 0; {
+			result = append(result, rest[:end])
+			_, size := utf8.DecodeLastRuneInString(rest[:end])
+			end -= size
+		}
 
-	if c > 1 {
-		result = append(result, getAllSubStrings(string(runes[1:]), maxLength)...)
+		_, size := utf8.DecodeRuneInString(rest)
+		start += size
 	}
 
 	return result
 }
 
+// The byte offset of the n-th rune of str, or its length.
+func runeOffset(str string, n int) int {
+	end := 0
+	for ; n > 0 && end < len(str); n-- {
+		_, size := utf8.DecodeRuneInString(str[end:])
+		end += size
+	}
+	return end
+}
+
+// Buffers of substrings reused across Process calls.
+var substringsPool = sync.Pool{
+	New: func() any {
+		return new([]string)
+	},
+}
+
+func putSubstrings(buf *[]string) {
+	// do not hold on to the buffer of a huge chunk
+	if cap(*buf) > 1<<16 {
+		return
+	}
+	*buf = (*buf)[:0]
+	substringsPool.Put(buf)
+}
+
+// Copies of strings, one per distinct string.
+type interner map[string]string
+
+func (in interner) intern(s string) string {
+	if t, ok := in[s]; ok {
+		return t
+	}
+	t := strings.Clone(s)
+	in[t] = t
+	return t
+}
+
 // Default stop words from set
 func stopWordsFromSets(sets []string) []string {
 	words := make([]string, 0)
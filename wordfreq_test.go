@@ -0,0 +1,200 @@
+package wordfreq
+
+import (
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// The regular expressions normalizeEnglishWord replaces.
+var (
+	engR1 = regexp.MustCompile("\\.+")                      // replace multiple full stops
+	engR2 = regexp.MustCompile("(.{3,})\\.$")               // replace single trailing stop
+	engR4 = regexp.MustCompile("(?i)[\\'’](s|ll|d|ve)?\\b") // get rid of ’ and '
+)
+
+func normalizeEnglishWordRegexp(word string) string {
+	word = engR1.ReplaceAllString(word, ".")
+	word = engR2.ReplaceAllString(word, "$1")
+	word = engR3.ReplaceAllString(word, "")
+	word = engR4.ReplaceAllString(word, "")
+	return word
+}
+
+func TestNormalizeEnglishWord(t *testing.T) {
+	alphabet := []rune("aAsSlLdDvVeEnNtTx0_é-@.'’\n\b")
+	tokens := 500000
+	if testing.Short() {
+		tokens = 10000
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < tokens; i++ {
+		runes := make([]rune, 1+r.Intn(10))
+		for j := range runes {
+			runes[j] = alphabet[r.Intn(len(alphabet))]
+		}
+		word := string(runes)
+
+		if got, want := normalizeEnglishWord(word), normalizeEnglishWordRegexp(word); got != want {
+			t.Fatalf("normalizeEnglishWord(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
+
+const benchmarkEnglish = "Compatibility of systems of linear constraints over the set of " +
+	"natural numbers. Criteria of compatibility of a system of linear Diophantine " +
+	"equations, strict inequations, and nonstrict inequations are considered. " +
+	"It's the user's job... don't they'll know o'clock U.S.A. e.g. state-of-the-art. "
+
+func BenchmarkProcessEnglish(b *testing.B) {
+	text := strings.Repeat(benchmarkEnglish, 100)
+	w, err := New(Options{Languages: []string{"english"}})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.SetBytes(int64(len(text)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w.Process(text)
+	}
+}
+
+func BenchmarkNormalizeEnglishWord(b *testing.B) {
+	words := strings.Fields(benchmarkEnglish)
+	for _, bench := range []struct {
+		name      string
+		normalize func(string) string
+	}{
+		{"scanner", normalizeEnglishWord},
+		{"regexp", normalizeEnglishWordRegexp},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for _, word := range words {
+					bench.normalize(word)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkAppendSubStrings(b *testing.B) {
+	chunk := strings.Repeat("中華民國台灣", 100)
+	buf := make([]string, 0)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = appendSubStrings(buf[:0], chunk, 8, 0)
+	}
+}
+
+func BenchmarkInterner(b *testing.B) {
+	substrings := appendSubStrings(nil, strings.Repeat("中華民國台灣", 100), 8, 0)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		in := make(interner)
+		for _, substring := range substrings {
+			in.intern(substring)
+		}
+	}
+}
+
+func BenchmarkSubstringsPool(b *testing.B) {
+	chunk := strings.Repeat("中華民國台灣", 100)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf := substringsPool.Get().(*[]string)
+		*buf = appendSubStrings((*buf)[:0], chunk, 8, 0)
+		putSubstrings(buf)
+	}
+}
+
+func BenchmarkProcessChinese(b *testing.B) {
+	text := strings.Repeat("中華民國台灣，中華民國的首都是台北。台灣的人口。", 100)
+	w, err := New(Options{Languages: []string{"chinese"}})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.SetBytes(int64(len(text)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w.Process(text)
+	}
+}
+
+// A chunk of megabytes is counted without recursing once per rune, the
+// same as short ones.
+func TestProcessLongChunk(t *testing.T) {
+	if testing.Short() {
+		t.Skip("9 MB of text")
+	}
+
+	runes := 3 << 20
+	text := strings.Repeat("甲乙丙", runes/3)
+	w, err := New(Options{Languages: []string{"chinese"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Process(text)
+
+	// every start but the last 7 begins a phrase of 8 characters
+	longest := 0
+	for term, n := range w.terms {
+		if len([]rune(term)) == 8 {
+			longest += n
+		}
+	}
+	if want := runes - 7; longest != want {
+		t.Errorf("%d phrases of 8 characters, want %d", longest, want)
+	}
+}
+
+// The parts of the compounds, within their spans, are not names
+// following them.
+func TestKeepNamesCompoundParts(t *testing.T) {
+	for _, test := range []struct {
+		ops  Options
+		text string
+	}{
+		{Options{KeepNames: true, HyphenMode: "both"}, "New-York is big. New-York again."},
+		{Options{KeepNames: true, CompoundLexicon: []string{"donau", "dampf", "schiff"}}, "Das Donaudampfschiff fährt. Das Donaudampfschiff."},
+	} {
+		w, err := New(test.ops)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(w.Process(test.text)) == 0 {
+			t.Errorf("%q: no terms", test.text)
+		}
+	}
+}
+
+// An override cannot start recording per document what the earlier
+// documents did not record.
+func TestProcessWithOptionsRecording(t *testing.T) {
+	for _, override := range []Options{
+		{RecordOccurrences: true},
+		{DocumentFrequency: true},
+		{DocumentVectors: true},
+	} {
+		w, err := New(Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Process("hello world")
+		if _, err := w.ProcessWithOptions("hello hello", override); err == nil {
+			t.Errorf("%+v: no error", override)
+		}
+		w.Concordance("hello", 3)
+		w.DocumentVector(1)
+	}
+}